@@ -354,8 +354,8 @@ func addAddressLookup() {
 		Example:     "22.921026",
 		Output:      "float",
 		Params: []Param{
-			{Field: "min", Display: "Min", Type: "float", Default: "0", Description: "Minimum range"},
-			{Field: "max", Display: "Max", Type: "float", Default: "90", Description: "Maximum range"},
+			{Field: "min", Display: "Min", Type: "float", Default: "0", Min: "-90", Max: "90", Description: "Minimum range"},
+			{Field: "max", Display: "Max", Type: "float", Default: "90", Min: "min", Max: "90", Description: "Maximum range"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			min, err := info.GetFloat64(m, "min")
@@ -395,8 +395,8 @@ func addAddressLookup() {
 		Example:     "-8.170450",
 		Output:      "float",
 		Params: []Param{
-			{Field: "min", Display: "Min", Type: "float", Default: "0", Description: "Minimum range"},
-			{Field: "max", Display: "Max", Type: "float", Default: "180", Description: "Maximum range"},
+			{Field: "min", Display: "Min", Type: "float", Default: "0", Min: "-180", Max: "180", Description: "Minimum range"},
+			{Field: "max", Display: "Max", Type: "float", Default: "180", Min: "min", Max: "180", Description: "Maximum range"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			min, err := info.GetFloat64(m, "min")
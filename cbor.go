@@ -0,0 +1,278 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// CBOROptions defines values needed for cbor generation
+type CBOROptions struct {
+	Type     string  `json:"type" xml:"type" fake:"{randomstring:[array,object]}"` // array or object
+	RowCount int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
+	Fields   []Field `json:"fields" xml:"fields" fake:"{fields}"`
+}
+
+// CBOR generates an object or an array of objects in cbor format.
+// Objects keep the order of their fields and maps use the deterministic key order of RFC 8949.
+// A nil CBOROptions returns a randomly structured CBOR.
+func CBOR(co *CBOROptions) ([]byte, error) { return cborFunc(GlobalFaker, co) }
+
+// CBOR generates an object or an array of objects in cbor format.
+// Objects keep the order of their fields and maps use the deterministic key order of RFC 8949.
+// A nil CBOROptions returns a randomly structured CBOR.
+func (f *Faker) CBOR(co *CBOROptions) ([]byte, error) { return cborFunc(f, co) }
+
+func cborFunc(f *Faker, co *CBOROptions) ([]byte, error) {
+	if co == nil {
+		// We didn't get a CBOROptions, so create a new random one
+		err := f.Struct(&co)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check to make sure they passed in a type
+	if co.Type != "array" && co.Type != "object" {
+		return nil, errors.New("invalid type, must be array or object")
+	}
+
+	if co.Fields == nil || len(co.Fields) <= 0 {
+		return nil, errors.New("must pass fields in order to build cbor object(s)")
+	}
+
+	var value any
+	if co.Type == "object" {
+		// Object only has one row for autoincrement
+		obj, err := f.fieldObject(co.Fields, 1, 0, binaryLeafValue)
+		if err != nil {
+			return nil, err
+		}
+		value = obj
+	} else {
+		// Make sure you set a row count
+		if co.RowCount <= 0 {
+			return nil, errors.New("must have row count")
+		}
+
+		rows := make([]any, co.RowCount)
+		for i := range rows {
+			obj, err := f.fieldObject(co.Fields, i+1, 0, binaryLeafValue)
+			if err != nil {
+				return nil, err
+			}
+			rows[i] = obj
+		}
+		value = rows
+	}
+
+	var buf bytes.Buffer
+	err := cborEncode(&buf, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// cborEncode will write a value using the smallest cbor encoding for its type
+func cborEncode(buf *bytes.Buffer, value any) error {
+	// Bytes that are not json are kept as a byte string
+	if b, ok := value.([]byte); ok {
+		cborHeader(buf, 2, uint64(len(b)))
+		buf.Write(b)
+		return nil
+	}
+
+	value, err := plainValue(value)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xf6)
+		return nil
+	case bool:
+		if v {
+			buf.WriteByte(0xf5)
+		} else {
+			buf.WriteByte(0xf4)
+		}
+		return nil
+	case string:
+		cborHeader(buf, 3, uint64(len(v)))
+		buf.WriteString(v)
+		return nil
+	case time.Time:
+		// Tag 1 is seconds since the epoch, an integer unless it has fractional seconds
+		cborHeader(buf, 6, 1)
+		if v.Nanosecond() == 0 {
+			return cborEncode(buf, v.Unix())
+		}
+		return cborEncode(buf, float64(v.UnixNano())/1e9)
+	case json.Number:
+		number, err := binaryNumber(v)
+		if err != nil {
+			return err
+		}
+		return cborEncode(buf, number)
+	case []any:
+		cborHeader(buf, 4, uint64(len(v)))
+		for _, item := range v {
+			err := cborEncode(buf, item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		return cborMap(buf, v)
+	}
+
+	if keys, values, ok := plainEntries(value); ok {
+		cborHeader(buf, 5, uint64(len(keys)))
+		for i, key := range keys {
+			err := cborEncode(buf, key)
+			if err != nil {
+				return err
+			}
+			err = cborEncode(buf, values[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v := rv.Int(); v < 0 {
+			// Negative integers are stored as -1 - n
+			cborHeader(buf, 1, uint64(-1-v))
+		} else {
+			cborHeader(buf, 0, uint64(v))
+		}
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cborHeader(buf, 0, rv.Uint())
+		return nil
+	case reflect.Float32:
+		buf.WriteByte(0xfa)
+		buf.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(rv.Float()))))
+		return nil
+	case reflect.Float64:
+		buf.WriteByte(0xfb)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(rv.Float())))
+		return nil
+	}
+
+	return fmt.Errorf("cbor cannot output type %T", value)
+}
+
+// cborMap will write a map with its keys sorted by their encoded bytes,
+// the core deterministic encoding order of RFC 8949
+func cborMap(buf *bytes.Buffer, m map[string]any) error {
+	type entry struct {
+		key   []byte
+		value any
+	}
+
+	entries := make([]entry, 0, len(m))
+	for key, value := range m {
+		var kb bytes.Buffer
+		cborHeader(&kb, 3, uint64(len(key)))
+		kb.WriteString(key)
+		entries = append(entries, entry{key: kb.Bytes(), value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].key, entries[j].key) < 0 })
+
+	cborHeader(buf, 5, uint64(len(entries)))
+	for _, e := range entries {
+		buf.Write(e.key)
+		err := cborEncode(buf, e.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cborHeader will write a major type with its argument in the fewest bytes
+func cborHeader(buf *bytes.Buffer, major byte, n uint64) {
+	major <<= 5
+	switch {
+	case n < 24:
+		buf.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		buf.Write([]byte{major | 24, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(major | 25)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	case n <= math.MaxUint32:
+		buf.WriteByte(major | 26)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	default:
+		buf.WriteByte(major | 27)
+		buf.Write(binary.BigEndian.AppendUint64(nil, n))
+	}
+}
+
+func addFileCBORLookup() {
+	AddFuncLookup("cbor", Info{
+		Display:     "CBOR",
+		Category:    "file",
+		Description: "Concise binary object representation, a compact binary format like json, returns an object or an array of objects",
+		Example:     "[]byte{0xa2, 0x6a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x66, 0x4d, 0x61, 0x72, 0x6b, 0x75, 0x73, ...}",
+		Output:      "[]byte",
+		ContentType: "application/cbor",
+		Params: []Param{
+			{Field: "type", Display: "Type", Type: "string", Default: "object", Options: []string{"object", "array"}, Description: "Type of CBOR, object or array"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of rows in CBOR array"},
+			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			co := CBOROptions{}
+
+			typ, err := info.GetString(m, "type")
+			if err != nil {
+				return nil, err
+			}
+			co.Type = typ
+
+			rowcount, err := info.GetInt(m, "rowcount")
+			if err != nil {
+				return nil, err
+			}
+			co.RowCount = rowcount
+
+			fieldsStr, err := info.GetStringArray(m, "fields")
+			if err != nil {
+				return nil, err
+			}
+
+			// Check to make sure fields has length
+			if len(fieldsStr) > 0 {
+				co.Fields = make([]Field, len(fieldsStr))
+
+				for i, f := range fieldsStr {
+					// Unmarshal fields string into fields array
+					err = json.Unmarshal([]byte(f), &co.Fields[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return cborFunc(f, &co)
+		},
+	})
+}
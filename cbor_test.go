@@ -0,0 +1,198 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func ExampleCBOR() {
+	Seed(11)
+
+	value, err := CBOR(&CBOROptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "active", Value: true},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%x\n", value)
+
+	// Output: a3626964016a66697273745f6e616d6565536f6e6e7966616374697665f5
+}
+
+func ExampleFaker_CBOR() {
+	f := New(11)
+
+	value, err := f.CBOR(&CBOROptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "active", Value: true},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%x\n", value)
+
+	// Output: a3626964016a66697273745f6e616d6565536f6e6e7966616374697665f5
+}
+
+func TestCBORValues(t *testing.T) {
+	// Values from the examples in appendix A of RFC 8949
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{1000000000000, "1b000000e8d4a51000"},
+		{uint64(math.MaxUint64), "1bffffffffffffffff"},
+		{-1, "20"},
+		{-10, "29"},
+		{-100, "3863"},
+		{-1000, "3903e7"},
+		{int64(math.MinInt64), "3b7fffffffffffffff"},
+		{1.1, "fb3ff199999999999a"},
+		{float32(100000.0), "fa47c35000"},
+		{math.Inf(1), "fb7ff0000000000000"},
+		{json.Number("1000"), "1903e8"},
+		{json.Number("-1.5"), "fbbff8000000000000"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{"", "60"},
+		{"a", "6161"},
+		{"IETF", "6449455446"},
+		{"ü", "62c3bc"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{[]any{}, "80"},
+		{[]any{1, 2, 3}, "83010203"},
+		{map[string]any{}, "a0"},
+		{map[string]any{"b": []any{2, 3}, "a": 1}, "a26161016162820203"},
+		{map[string]any{"aa": 2, "b": 1}, "a2616201626161" + "02"},
+		{jsonOrderedKeyVal{{Key: "b", Value: 1}, {Key: "a", Value: 2}}, "a2616201616102"},
+		{time.Unix(1363896240, 0), "c11a514b67b0"},
+		{time.Unix(1363896240, 500000000), "c1fb41d452d9ec200000"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		err := cborEncode(&buf, test.value)
+		if err != nil {
+			t.Errorf("%v: %s", test.value, err)
+			continue
+		}
+		if got := hex.EncodeToString(buf.Bytes()); got != test.want {
+			t.Errorf("%#v: expected %s, got %s", test.value, test.want, got)
+		}
+	}
+}
+
+func TestCBORArray(t *testing.T) {
+	value, err := New(11).CBOR(&CBOROptions{
+		Type:     "array",
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "tags", Function: "word", Count: 2},
+			{Name: "meta", Function: "json", Params: MapParams{"type": {"object"}, "fields": {`{"name":"n","value":1}`}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Array of 3 maps with 3 keys, starting with id 1
+	if !bytes.HasPrefix(value, []byte{0x83, 0xa3, 0x62, 'i', 'd', 0x01, 0x64, 't', 'a', 'g', 's', 0x82}) {
+		t.Errorf("unexpected cbor %x", value)
+	}
+
+	// Json bytes are decoded into a map instead of a byte string
+	if !bytes.Contains(value, []byte{0x64, 'm', 'e', 't', 'a', 0xa1, 0x61, 'n', 0x01}) {
+		t.Errorf("expected meta map in %x", value)
+	}
+}
+
+func TestCBORErrors(t *testing.T) {
+	fields := []Field{{Name: "id", Function: "autoincrement"}}
+
+	tests := map[string]*CBOROptions{
+		"invalid type":  {Type: "list", Fields: fields},
+		"no fields":     {Type: "object"},
+		"no row count":  {Type: "array", Fields: fields},
+		"invalid field": {Type: "object", Fields: []Field{{Name: "x", Function: "notafunction"}}},
+	}
+
+	for name, co := range tests {
+		_, err := New(11).CBOR(co)
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if err := cborEncode(&bytes.Buffer{}, make(chan int)); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestCBORNil(t *testing.T) {
+	_, err := New(11).CBOR(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCBORLookup(t *testing.T) {
+	info := GetFuncLookup("cbor")
+
+	m := MapParams{
+		"type":     {"array"},
+		"rowcount": {"2"},
+		"fields":   {`{"name":"id","function":"autoincrement"}`},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0x82, 0xa1, 0x62, 'i', 'd', 0x01, 0xa1, 0x62, 'i', 'd', 0x02}
+	if !bytes.Equal(value.([]byte), want) {
+		t.Errorf("expected %x, got %x", want, value)
+	}
+}
+
+func BenchmarkCBOR(b *testing.B) {
+	f := New(11)
+	co := &CBOROptions{
+		Type:     "array",
+		RowCount: 10,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "price", Function: "price"},
+			{Name: "created_at", Function: "date"},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		f.CBOR(co)
+	}
+}
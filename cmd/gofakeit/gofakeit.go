@@ -75,6 +75,7 @@ func mainFunc(seed uint64, args []string, loop int) (string, error) {
 		sb.WriteString("    gofakeit list [category]\n")
 		sb.WriteString("    gofakeit [function] [args]\n")
 		sb.WriteString("    gofakeit [function] -loop=5\n")
+		sb.WriteString("    gofakeit run [scenario.json]\n")
 		sb.WriteString("\n")
 		sb.WriteString("DESCRIPTION\n")
 		sb.WriteString("    gofakeit is a set of functions that allow you to generate random data.\n")
@@ -96,6 +97,15 @@ func mainFunc(seed uint64, args []string, loop int) (string, error) {
 		return listOutput(selectedCat, selectedFunc), nil
 	}
 
+	// Run a scenario file of entities and the files to write them to
+	if function == "run" {
+		if argsLen < 2 {
+			return "", errNoScenarioMsg
+		}
+
+		return runScenario(args[1])
+	}
+
 	// Loop through loop and append to output and join on \n
 	sa := []string{}
 	for i := 0; i < loop; i++ {
@@ -113,8 +123,13 @@ func runFunction(faker *gofakeit.Faker, function string, args []string) (string,
 	argsLen := len(args)
 
 	// Lookup fake data method
-	info := gofakeit.GetFuncLookup(function)
+	info := faker.GetFuncLookup(function)
 	if info == nil {
+		// Run generate patterns directly. Ex: gofakeit "{active:80|deleted:20}"
+		if strings.ContainsAny(function, "{[#?") {
+			return faker.Generate(function)
+		}
+
 		return "", errNoFuncRunMsg
 	}
 
@@ -142,6 +157,12 @@ func runFunction(faker *gofakeit.Faker, function string, args []string) (string,
 		}
 	}
 
+	// Validate params against their constraints
+	err := info.ValidateParams(params)
+	if err != nil {
+		return "", err
+	}
+
 	value, err := info.Generate(faker, params, info)
 	if err != nil {
 		return "", err
@@ -220,6 +241,21 @@ func listOutput(selectedCategory string, selectedFunction string) string {
 				sb.WriteString(fmt.Sprintf("Name: %s", p.Field))
 				sb.WriteString(fmt.Sprintf(" Type: %s", p.Type))
 				sb.WriteString(fmt.Sprintf(" Default: %s", p.Default))
+				if p.Min != "" {
+					sb.WriteString(fmt.Sprintf(" Min: %s", p.Min))
+				}
+				if p.Max != "" {
+					sb.WriteString(fmt.Sprintf(" Max: %s", p.Max))
+				}
+				if p.Pattern != "" {
+					sb.WriteString(fmt.Sprintf(" Pattern: %s", p.Pattern))
+				}
+				if p.MinItems > 0 {
+					sb.WriteString(fmt.Sprintf(" Min Items: %d", p.MinItems))
+				}
+				if p.MaxItems > 0 {
+					sb.WriteString(fmt.Sprintf(" Max Items: %d", p.MaxItems))
+				}
 				sb.WriteString(fmt.Sprintf(" Description: %s", p.Description))
 				outArray = append(outArray, sb.String())
 			}
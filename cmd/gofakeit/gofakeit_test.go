@@ -127,6 +127,20 @@ func TestFunctionWithParams(t *testing.T) {
 	}
 }
 
+func TestFunctionInvalidParams(t *testing.T) {
+	seed := uint64(11)
+	args := []string{"number", "10", "1"}
+
+	_, err := mainFunc(seed, args, 1)
+	if err == nil {
+		t.Fatal("Expected error, got no error")
+	}
+
+	if !strings.Contains(err.Error(), "max") {
+		t.Errorf("Expected error to name the max param, got %v", err)
+	}
+}
+
 func TestHelp(t *testing.T) {
 	seed := uint64(11)
 	args := []string{"help"}
@@ -215,3 +229,16 @@ func TestListCategoryFunction(t *testing.T) {
 		t.Errorf("list category function output does not contain random noun")
 	}
 }
+
+func TestFunctionPattern(t *testing.T) {
+	outStr, err := mainFunc(11, []string{"{active:80|suspended:20}"}, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, v := range strings.Split(outStr, "\n") {
+		if v != "active" && v != "suspended" {
+			t.Errorf("unexpected value %s", v)
+		}
+	}
+}
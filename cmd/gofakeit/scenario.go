@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+var errNoScenarioMsg = errors.New("must pass a scenario file to run\nrun gofakeit run scenario.json")
+
+// scenarioSeed is the seed used when a scenario doesnt set one, so every run outputs the same data
+const scenarioSeed uint64 = 1
+
+// scenario declares a dataset of related entities and the files to write it to
+type scenario struct {
+	Seed     *uint64                     `json:"seed"`     // Seed for the faker, defaults to scenarioSeed and 0 uses a random seed
+	Groups   map[string][]gofakeit.Field `json:"groups"`   // Field groups entities can nest with ref
+	Entities []gofakeit.DatasetTable     `json:"entities"` // Entities with their fields, count and references
+	Outputs  []scenarioOutput            `json:"outputs"`  // Files to write the generated entities to
+}
+
+// scenarioOutput is a file or directory the generated entities are written to
+type scenarioOutput struct {
+	Format string `json:"format"` // csv, json, sql or xml
+	Path   string `json:"path"`   // File path, csv and xml without an entity write a file per entity to this directory
+	Entity string `json:"entity"` // Only write this entity, csv and xml only
+	Indent bool   `json:"indent"` // Add indents and newlines to json
+
+	SQL *gofakeit.SQLOptions `json:"sql"` // Dialect, batch size and create table options for sql
+	CSV *gofakeit.CSVOptions `json:"csv"` // Delimiter, header and quoting options for csv
+	XML *gofakeit.XMLOptions `json:"xml"` // Element names and declaration options for xml
+}
+
+// runScenario will validate a scenario file, generate its entities and write each output.
+// Relative output paths are relative to the scenario file
+func runScenario(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var sc scenario
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	err = dec.Decode(&sc)
+	if err != nil {
+		return "", fmt.Errorf("invalid scenario %s: %s", path, err.Error())
+	}
+
+	seed := scenarioSeed
+	if sc.Seed != nil {
+		seed = *sc.Seed
+	}
+
+	// Groups are only added to the faker running the scenario
+	f := gofakeit.New(seed)
+	for name, fields := range sc.Groups {
+		f.AddFieldGroup(name, fields)
+	}
+
+	if errs := sc.validate(f); len(errs) > 0 {
+		return "", fmt.Errorf("invalid scenario %s\n%s", path, strings.Join(errs, "\n"))
+	}
+
+	result, err := f.Dataset(&gofakeit.DatasetOptions{Tables: sc.Entities})
+	if err != nil {
+		return "", err
+	}
+
+	var written []string
+	dir := filepath.Dir(path)
+	for _, out := range sc.Outputs {
+		files, err := out.write(result, dir)
+		if err != nil {
+			return "", err
+		}
+
+		for _, file := range files {
+			written = append(written, "wrote "+file)
+		}
+	}
+
+	return strings.Join(written, "\n"), nil
+}
+
+// validate will check entities, fields and outputs against the function lookups
+// before anything is generated and return every problem found
+func (sc *scenario) validate(f *gofakeit.Faker) []string {
+	var errs []string
+
+	if len(sc.Entities) == 0 {
+		errs = append(errs, "entities: must declare at least one entity")
+	}
+
+	names := map[string]bool{}
+	for _, entity := range sc.Entities {
+		names[entity.Name] = true
+	}
+
+	for i, entity := range sc.Entities {
+		path := fmt.Sprintf("entities[%d]", i)
+		if entity.Name == "" {
+			errs = append(errs, path+": name is required")
+		} else {
+			path = "entities." + entity.Name
+		}
+
+		perParent := false
+		for ii, ref := range entity.References {
+			refPath := fmt.Sprintf("%s.references[%d]", path, ii)
+			if ref.Field == "" {
+				errs = append(errs, refPath+": field is required")
+			}
+			if !names[ref.Table] {
+				errs = append(errs, fmt.Sprintf("%s: entity %s does not exist", refPath, ref.Table))
+			}
+			if ref.Count > 0 || ref.CountMax > 0 {
+				perParent = true
+			}
+		}
+
+		if entity.Count <= 0 && !perParent {
+			errs = append(errs, path+": count must be greater than 0 unless a reference sets a count per parent")
+		}
+
+		errs = append(errs, validateFields(f, path, entity.Fields)...)
+	}
+
+	groups := make([]string, 0, len(sc.Groups))
+	for name := range sc.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		errs = append(errs, validateFields(f, "groups."+name, sc.Groups[name])...)
+	}
+
+	if len(sc.Outputs) == 0 {
+		errs = append(errs, "outputs: must declare at least one output")
+	}
+
+	for i, out := range sc.Outputs {
+		path := fmt.Sprintf("outputs[%d]", i)
+		switch out.Format {
+		case "csv", "xml":
+			if out.Entity != "" && !names[out.Entity] {
+				errs = append(errs, fmt.Sprintf("%s: entity %s does not exist", path, out.Entity))
+			}
+		case "json", "sql":
+			if out.Entity != "" {
+				errs = append(errs, path+": entity is only supported by csv and xml")
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("%s: invalid format %s, must be csv, json, sql or xml", path, out.Format))
+		}
+
+		if out.Path == "" {
+			errs = append(errs, path+": path is required")
+		}
+	}
+
+	return errs
+}
+
+// validateFields will check every field, and its nested fields, calls a function that exists with valid params
+func validateFields(f *gofakeit.Faker, path string, fields []gofakeit.Field) []string {
+	var errs []string
+	for i, field := range fields {
+		fieldPath := fmt.Sprintf("%s.fields[%d]", path, i)
+		if field.Name == "" {
+			errs = append(errs, fieldPath+": name is required")
+		} else {
+			fieldPath = path + "." + field.Name
+		}
+
+		// Nested fields, refs and static values dont call a function
+		if len(field.Fields) > 0 {
+			errs = append(errs, validateFields(f, fieldPath, field.Fields)...)
+			continue
+		}
+		if field.Ref != "" {
+			if f.GetFieldGroup(field.Ref) == nil {
+				errs = append(errs, fmt.Sprintf("%s: group %s does not exist", fieldPath, field.Ref))
+			}
+			continue
+		}
+		if field.Value != nil || field.Function == "autoincrement" {
+			continue
+		}
+
+		if field.Function == "" {
+			errs = append(errs, fieldPath+": function is required")
+			continue
+		}
+
+		info := f.GetFuncLookup(field.Function)
+		if info == nil {
+			// Generate patterns dont have a lookup. Ex: ###-???
+			if !strings.ContainsAny(field.Function, "{[#?") {
+				errs = append(errs, fmt.Sprintf("%s: function %s does not exist", fieldPath, field.Function))
+			}
+			continue
+		}
+
+		errs = append(errs, validateParams(fieldPath, field.Function, info, field.Params)...)
+	}
+
+	return errs
+}
+
+// validateParams will check params are known by the function, required params are set and values are valid
+func validateParams(path string, function string, info *gofakeit.Info, params gofakeit.MapParams) []string {
+	var errs []string
+
+	// Sort param names so errors are always in the same order
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		known := false
+		for _, p := range info.Params {
+			if p.Field == key {
+				known = true
+				break
+			}
+		}
+		if !known {
+			errs = append(errs, fmt.Sprintf("%s: function %s has no param %s", path, function, key))
+		}
+	}
+
+	for _, p := range info.Params {
+		if _, ok := params[p.Field]; !ok && p.Default == "" && !p.Optional {
+			errs = append(errs, fmt.Sprintf("%s: function %s requires param %s", path, function, p.Field))
+		}
+	}
+
+	if err := info.ValidateParams(&params); err != nil {
+		errs = append(errs, fmt.Sprintf("%s: %s", path, err.Error()))
+	}
+
+	return errs
+}
+
+// write will write the generated entities and return the paths of the files written
+func (out *scenarioOutput) write(result *gofakeit.DatasetResult, dir string) ([]string, error) {
+	path := out.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	switch out.Format {
+	case "sql":
+		value, err := result.SQL(out.SQL)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, writeScenarioFile(path, []byte(value+"\n"))
+	case "json":
+		value, err := result.JSON(out.Indent)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, writeScenarioFile(path, value)
+	}
+
+	// csv and xml write a file per entity unless an entity is set
+	var names []string
+	if out.Entity != "" {
+		names = []string{out.Entity}
+	} else {
+		for _, rows := range result.Tables {
+			names = append(names, rows.Name)
+		}
+	}
+
+	var files []string
+	for _, name := range names {
+		file := path
+		if out.Entity == "" {
+			file = filepath.Join(path, name+"."+out.Format)
+		}
+
+		var buf bytes.Buffer
+		var err error
+		if out.Format == "csv" {
+			err = result.WriteCSV(&buf, name, out.CSV)
+		} else {
+			err = result.WriteXML(&buf, name, out.XML)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeScenarioFile(file, buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// writeScenarioFile will write a file, creating its directory if needed
+func writeScenarioFile(path string, b []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+const testScenario = `{
+	"seed": 11,
+	"groups": {
+		"address": [
+			{"name": "city", "function": "city"},
+			{"name": "zip", "function": "zip"}
+		]
+	},
+	"entities": [
+		{
+			"name": "orders",
+			"fields": [
+				{"name": "id", "function": "autoincrement"},
+				{"name": "total", "function": "price", "params": {"min": ["1"], "max": ["100"]}},
+				{"name": "code", "function": "###-???"}
+			],
+			"references": [{"field": "user_id", "table": "users", "count": 1, "count_max": 3}]
+		},
+		{
+			"name": "users",
+			"count": 5,
+			"fields": [
+				{"name": "id", "function": "autoincrement"},
+				{"name": "email", "function": "email"},
+				{"name": "address", "ref": "address"}
+			]
+		}
+	],
+	"outputs": [
+		{"format": "csv", "path": "csv"},
+		{"format": "json", "path": "data.json", "indent": true},
+		{"format": "sql", "path": "seed.sql", "sql": {"dialect": "postgres", "create_table": true}},
+		{"format": "xml", "path": "users.xml", "entity": "users", "xml": {"record_element": "user"}}
+	]
+}`
+
+func writeTestScenario(t *testing.T, scenario string) string {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	err := os.WriteFile(path, []byte(scenario), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestRunScenario(t *testing.T) {
+	path := writeTestScenario(t, testScenario)
+	dir := filepath.Dir(path)
+
+	out, err := mainFunc(0, []string{"run", path}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{"csv/users.csv", "csv/orders.csv", "data.json", "seed.sql", "users.xml"} {
+		if !strings.Contains(out, filepath.Join(dir, file)) {
+			t.Errorf("expected %s in output %s", file, out)
+		}
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Users are generated before the orders that reference them
+	sql, _ := os.ReadFile(filepath.Join(dir, "seed.sql"))
+	if strings.Index(string(sql), `INSERT INTO "users"`) > strings.Index(string(sql), `INSERT INTO "orders"`) {
+		t.Errorf("expected users before orders %s", sql)
+	}
+	if !strings.Contains(string(sql), `FOREIGN KEY ("user_id") REFERENCES "users" ("id")`) {
+		t.Errorf("expected foreign key %s", sql)
+	}
+
+	var data map[string][]map[string]any
+	b, _ := os.ReadFile(filepath.Join(dir, "data.json"))
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data["users"]) != 5 || data["users"][0]["address"] == nil {
+		t.Errorf("unexpected users %v", data["users"])
+	}
+
+	csv, _ := os.ReadFile(filepath.Join(dir, "csv", "orders.csv"))
+	if !strings.HasPrefix(string(csv), "id,total,code,user_id\n") {
+		t.Errorf("unexpected orders csv %s", csv)
+	}
+
+	xml, _ := os.ReadFile(filepath.Join(dir, "users.xml"))
+	if !strings.HasPrefix(string(xml), "<users><user><id>1</id>") {
+		t.Errorf("unexpected users xml %s", xml)
+	}
+}
+
+func TestRunScenarioDeterministic(t *testing.T) {
+	var outputs []string
+	for i := 0; i < 2; i++ {
+		path := writeTestScenario(t, testScenario)
+		_, err := mainFunc(0, []string{"run", path}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, _ := os.ReadFile(filepath.Join(filepath.Dir(path), "data.json"))
+		outputs = append(outputs, string(b))
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Errorf("expected the same seed to generate the same data\n%s\n%s", outputs[0], outputs[1])
+	}
+
+	// Scenarios without a seed use the default seed
+	noSeed := strings.Replace(testScenario, `"seed": 11,`, "", 1)
+	outputs = nil
+	for i := 0; i < 2; i++ {
+		path := writeTestScenario(t, noSeed)
+		_, err := mainFunc(0, []string{"run", path}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, _ := os.ReadFile(filepath.Join(filepath.Dir(path), "data.json"))
+		outputs = append(outputs, string(b))
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Errorf("expected the default seed to generate the same data\n%s\n%s", outputs[0], outputs[1])
+	}
+}
+
+func TestRunScenarioGroups(t *testing.T) {
+	_, err := mainFunc(0, []string{"run", writeTestScenario(t, testScenario)}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Groups are only added for the run
+	if gofakeit.GetFieldGroup("address") != nil {
+		t.Error("expected scenario groups to not be added globally")
+	}
+}
+
+func TestRunScenarioInvalid(t *testing.T) {
+	path := writeTestScenario(t, `{
+		"entities": [
+			{"name": "users", "fields": [
+				{"name": "id", "function": "autoincrement"},
+				{"name": "first", "function": "fistname"},
+				{"name": "age", "function": "number", "params": {"minimum": ["1"]}},
+				{"name": "home", "ref": "nowhere"}
+			]},
+			{"name": "orders", "count": 1, "references": [{"field": "user_id", "table": "customers"}]}
+		],
+		"outputs": [{"format": "yaml", "path": "out.yaml"}, {"format": "sql", "entity": "users"}]
+	}`)
+
+	_, err := mainFunc(0, []string{"run", path}, 1)
+	if err == nil {
+		t.Fatal("expected invalid scenario error")
+	}
+
+	for _, want := range []string{
+		"entities.users: count must be greater than 0",
+		"entities.users.first: function fistname does not exist",
+		"entities.users.age: function number has no param minimum",
+		"entities.users.home: group nowhere does not exist",
+		"entities.orders.references[0]: entity customers does not exist",
+		"outputs[0]: invalid format yaml",
+		"outputs[1]: entity is only supported by csv and xml",
+		"outputs[1]: path is required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error %s", want, err.Error())
+		}
+	}
+
+	// Nothing is written when the scenario is invalid
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "out.yaml")); err == nil {
+		t.Error("expected no output to be written")
+	}
+}
+
+func TestRunScenarioErrors(t *testing.T) {
+	if _, err := mainFunc(0, []string{"run"}, 1); err == nil {
+		t.Error("expected error without a scenario file")
+	}
+	if _, err := mainFunc(0, []string{"run", "notafile.json"}, 1); err == nil {
+		t.Error("expected error for a missing scenario file")
+	}
+	if _, err := mainFunc(0, []string{"run", writeTestScenario(t, `{"entities": [], "unknown": true}`)}, 1); err == nil {
+		t.Error("expected error for an unknown key")
+	}
+}
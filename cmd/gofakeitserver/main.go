@@ -82,6 +82,13 @@ func lookupGet(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Validate params against their constraints
+	err = info.ValidateParams(m)
+	if err != nil {
+		badrequest(w, err.Error())
+		return
+	}
+
 	// Generate requested data
 	data, err := info.Generate(faker, m, info)
 	if err != nil {
@@ -148,6 +155,13 @@ func lookupPost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Validate params against their constraints
+	err = info.ValidateParams(mapString)
+	if err != nil {
+		badrequest(w, err.Error())
+		return
+	}
+
 	// Generate requested data
 	data, err := info.Generate(faker, mapString, info)
 	if err != nil {
@@ -165,7 +179,7 @@ func getInfoFromPath(r *http.Request) (*gofakeit.Info, error) {
 	}
 
 	// Lookup fake data method
-	info := gofakeit.GetFuncLookup(paths[0])
+	info := faker.GetFuncLookup(paths[0])
 	if info == nil {
 		return nil, errors.New("No function was called, please pass func parameter")
 	}
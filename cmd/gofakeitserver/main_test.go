@@ -93,6 +93,12 @@ func TestGetAllRequests(t *testing.T) {
 					mapData.Add(p.Field, fmt.Sprintf("%v", gofakeit.Float32()))
 				case "[]Field":
 					mapData.Add(p.Field, `{"name":"first_name","function":"firstname"}`)
+				case "[]DatasetTable":
+					mapData.Add(p.Field, `{"name":"users","count":1,"fields":[{"name":"first_name","function":"firstname"}]}`)
+				case "[]FixedWidthColumn":
+					mapData.Add(p.Field, `{"width":20}`)
+				case "FixedWidthRecord":
+					mapData.Add(p.Field, `{"fields":[{"name":"count","function":"recordcount"}],"columns":[{"width":10,"pad":"zero"}]}`)
 				default:
 					t.Fatalf("Looking for %s but switch case doesnt have it", p.Type)
 				}
@@ -161,6 +167,29 @@ func TestGetLookupWithParams(t *testing.T) {
 	}
 }
 
+func TestGetLookupInvalidParams(t *testing.T) {
+	var response string
+	var statusCode int
+	testRequest(&testRequestStruct{
+		Testing: t,
+		Method:  "GET",
+		Path:    "/sentence",
+		QueryParams: url.Values{
+			"wordcount": []string{"-5"},
+		},
+		Response:   &response,
+		StatusCode: &statusCode,
+	})
+
+	if statusCode != 400 {
+		t.Fatalf("Was expecting 400 got %d", statusCode)
+	}
+
+	if !strings.Contains(response, "wordcount") {
+		t.Fatalf("Was expecting error to name the wordcount param got %s", response)
+	}
+}
+
 func TestPostAllRequests(t *testing.T) {
 	for field, info := range gofakeit.FuncLookups {
 		var mapData map[string][]string
@@ -210,6 +239,12 @@ func TestPostAllRequests(t *testing.T) {
 					mapData[p.Field] = []string{fmt.Sprintf("%v", gofakeit.Float32()), fmt.Sprintf("%v", gofakeit.Float32()), fmt.Sprintf("%v", gofakeit.Float32()), fmt.Sprintf("%v", gofakeit.Float32())}
 				case "[]Field":
 					mapData[p.Field] = []string{`{"name":"first_name","function":"firstname"}`}
+				case "[]DatasetTable":
+					mapData[p.Field] = []string{`{"name":"users","count":1,"fields":[{"name":"first_name","function":"firstname"}]}`}
+				case "[]FixedWidthColumn":
+					mapData[p.Field] = []string{`{"width":20}`}
+				case "FixedWidthRecord":
+					mapData[p.Field] = []string{`{"fields":[{"name":"count","function":"recordcount"}],"columns":[{"width":10,"pad":"zero"}]}`}
 				default:
 					t.Fatalf("Looking for %s but switch case doesnt have it", p.Type)
 				}
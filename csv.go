@@ -1,20 +1,31 @@
 package gofakeit
 
 import (
+	"bufio"
 	"bytes"
-	"encoding/csv"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // CSVOptions defines values needed for csv generation
 type CSVOptions struct {
-	Delimiter string  `json:"delimiter" xml:"delimiter" fake:"{randomstring:[,,tab]}"`
-	RowCount  int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
-	Fields    []Field `json:"fields" xml:"fields" fake:"{fields}"`
+	Delimiter  string  `json:"delimiter" xml:"delimiter" fake:"{randomstring:[,,tab]}"` // Any single character or tab
+	RowCount   int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
+	Fields     []Field `json:"fields" xml:"fields" fake:"{fields}"`
+	NoHeader   bool    `json:"no_header" xml:"no_header" fake:"skip"`     // Skip the header row
+	QuoteAll   bool    `json:"quote_all" xml:"quote_all" fake:"skip"`     // Quote every value, not just those that need it
+	CRLF       bool    `json:"crlf" xml:"crlf" fake:"skip"`               // End lines with \r\n instead of \n
+	BOM        bool    `json:"bom" xml:"bom" fake:"skip"`                 // Start with a UTF-8 byte order mark
+	Null       string  `json:"null" xml:"null" fake:"skip"`               // Output for nil values. Ex: \N or NULL
+	TimeLayout string  `json:"time_layout" xml:"time_layout" fake:"skip"` // Go time layout for time values. Ex: 2006-01-02
 }
 
 // CSV generates an object or an array of objects in json format
@@ -26,14 +37,61 @@ func CSV(co *CSVOptions) ([]byte, error) { return csvFunc(GlobalFaker, co) }
 func (f *Faker) CSV(co *CSVOptions) ([]byte, error) { return csvFunc(f, co) }
 
 func csvFunc(f *Faker, co *CSVOptions) ([]byte, error) {
+	b := &bytes.Buffer{}
+	err := writeCSV(context.Background(), f, b, co, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// WriteCSV streams csv rows to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each row.
+// A nil CSVOptions writes a randomly structured CSV.
+func WriteCSV(ctx context.Context, w io.Writer, co *CSVOptions, progress WriteProgress) error {
+	return writeCSV(ctx, GlobalFaker, w, co, progress)
+}
+
+// WriteCSV streams csv rows to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each row.
+// A nil CSVOptions writes a randomly structured CSV.
+func (f *Faker) WriteCSV(ctx context.Context, w io.Writer, co *CSVOptions, progress WriteProgress) error {
+	return writeCSV(ctx, f, w, co, progress)
+}
+
+func writeCSV(ctx context.Context, f *Faker, out io.Writer, co *CSVOptions, progress WriteProgress) error {
 	if co == nil {
 		// We didn't get a CSVOptions, so create a new random one
 		err := f.Struct(&co)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
+	// Check fields
+	if co.Fields == nil || len(co.Fields) <= 0 {
+		return errors.New("must pass fields in order to build json object(s)")
+	}
+
+	// Make sure you set a row count
+	if co.RowCount <= 0 {
+		return errors.New("must have row count")
+	}
+
+	return csvWriteRows(ctx, out, co, func(row int, col int) (any, error) {
+		if co.Fields[col].Function == "autoincrement" {
+			return row + 1, nil
+		}
+
+		value, _, err := f.callField(&co.Fields[col])
+		return value, err
+	}, progress)
+}
+
+// csvWriteRows will write the header, unless disabled, followed by co.RowCount
+// rows with each value returned by cell
+func csvWriteRows(ctx context.Context, out io.Writer, co *CSVOptions, cell func(row int, col int) (any, error), progress WriteProgress) error {
 	// Check delimiter
 	if co.Delimiter == "" {
 		co.Delimiter = ","
@@ -41,87 +99,172 @@ func csvFunc(f *Faker, co *CSVOptions) ([]byte, error) {
 	if strings.ToLower(co.Delimiter) == "tab" {
 		co.Delimiter = "\t"
 	}
-	if co.Delimiter != "," && co.Delimiter != "\t" && co.Delimiter != ";" {
-		return nil, errors.New("invalid delimiter type")
+	comma, size := utf8.DecodeRuneInString(co.Delimiter)
+	if size != len(co.Delimiter) || comma == utf8.RuneError || comma == '"' || comma == '\r' || comma == '\n' {
+		return errors.New("invalid delimiter type")
 	}
 
-	// Check fields
-	if co.Fields == nil || len(co.Fields) <= 0 {
-		return nil, errors.New("must pass fields in order to build json object(s)")
-	}
+	w := &csvWriter{w: bufio.NewWriter(out), comma: comma, quoteAll: co.QuoteAll, crlf: co.CRLF}
 
-	// Make sure you set a row count
-	if co.RowCount <= 0 {
-		return nil, errors.New("must have row count")
+	if co.BOM {
+		w.w.WriteString("\uFEFF")
 	}
 
-	b := &bytes.Buffer{}
-	w := csv.NewWriter(b)
-	w.Comma = []rune(co.Delimiter)[0]
-
 	// Add header row
-	header := make([]string, len(co.Fields))
-	for i, field := range co.Fields {
-		header[i] = field.Name
+	if !co.NoHeader {
+		header := make([]string, len(co.Fields))
+		for i, field := range co.Fields {
+			header[i] = field.Name
+		}
+		w.write(header, nil)
 	}
-	w.Write(header)
 
-	// Loop through row count +1(for header) and add fields
-	for i := 1; i < co.RowCount+1; i++ {
-		vr := make([]string, len(co.Fields))
+	vr := make([]string, len(co.Fields))
+	nulls := make([]bool, len(co.Fields))
 
-		// Loop through fields and add to them to map[string]any
-		for ii, field := range co.Fields {
-			if field.Function == "autoincrement" {
-				vr[ii] = fmt.Sprintf("%d", i)
-				continue
-			}
+	for i := 0; i < co.RowCount; i++ {
+		if err := ctx.Err(); err != nil {
+			w.w.Flush()
+			return err
+		}
 
-			// Get function info
-			funcInfo := GetFuncLookup(field.Function)
-			if funcInfo == nil {
-				return nil, errors.New("invalid function, " + field.Function + " does not exist")
+		// Loop through fields and convert each value
+		for ii := range co.Fields {
+			value, err := cell(i, ii)
+			if err != nil {
+				return err
 			}
 
-			value, err := funcInfo.Generate(f, &field.Params, funcInfo)
+			vr[ii], nulls[ii], err = csvValue(value, co)
 			if err != nil {
-				return nil, err
+				return err
 			}
+		}
 
-			if _, ok := value.([]byte); ok {
-				// If it's a slice of bytes or struct, unmarshal it into an interface
-				var v any
-				if err := json.Unmarshal(value.([]byte), &v); err != nil {
-					return nil, err
-				}
-				value = v
-			}
+		if err := w.write(vr, nulls); err != nil {
+			return err
+		}
 
-			// If the value is a list of possible values, marsha it into a string
-			if reflect.TypeOf(value).Kind() == reflect.Struct ||
-				reflect.TypeOf(value).Kind() == reflect.Ptr ||
-				reflect.TypeOf(value).Kind() == reflect.Map ||
-				reflect.TypeOf(value).Kind() == reflect.Slice {
-				b, err := json.Marshal(value)
-				if err != nil {
-					return nil, err
-				}
-				value = string(b)
-			}
+		if progress != nil {
+			progress(i+1, co.RowCount)
+		}
+	}
+
+	return w.w.Flush()
+}
+
+// csvValue will convert a field value into its csv string and whether it is null
+func csvValue(value any, co *CSVOptions) (string, bool, error) {
+	if value == nil {
+		return co.Null, true, nil
+	}
 
-			vr[ii] = fmt.Sprintf("%v", value)
+	if _, ok := value.([]byte); ok {
+		// If it's a slice of bytes or struct, unmarshal it into an interface
+		var v any
+		if err := json.Unmarshal(value.([]byte), &v); err != nil {
+			return "", false, err
 		}
+		if v == nil {
+			return co.Null, true, nil
+		}
+		value = v
+	}
 
-		w.Write(vr)
+	// Nil pointers, maps and slices are null
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if rv.IsNil() {
+			return co.Null, true, nil
+		}
 	}
 
-	w.Flush()
+	// Format time values with the time layout
+	if co.TimeLayout != "" {
+		switch t := value.(type) {
+		case time.Time:
+			return t.Format(co.TimeLayout), false, nil
+		case *time.Time:
+			return t.Format(co.TimeLayout), false, nil
+		}
+	}
 
-	if err := w.Error(); err != nil {
-		return nil, err
+	// If the value is a list of possible values, marsha it into a string
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Map, reflect.Slice:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), false, nil
 	}
 
-	return b.Bytes(), nil
+	return fmt.Sprintf("%v", value), false, nil
+}
+
+// csvWriter writes records the same as encoding/csv with the
+// addition of quoting every value and leaving null values unquoted
+type csvWriter struct {
+	w        *bufio.Writer
+	comma    rune
+	quoteAll bool
+	crlf     bool
+}
+
+func (cw *csvWriter) write(record []string, nulls []bool) error {
+	for i, field := range record {
+		if i > 0 {
+			cw.w.WriteRune(cw.comma)
+		}
+
+		if (nulls != nil && nulls[i]) || !(cw.quoteAll || cw.needsQuotes(field)) {
+			cw.w.WriteString(field)
+			continue
+		}
+
+		cw.w.WriteByte('"')
+		for _, r := range field {
+			switch r {
+			case '"':
+				cw.w.WriteString(`""`)
+			case '\r':
+				if !cw.crlf {
+					cw.w.WriteByte('\r')
+				}
+			case '\n':
+				if cw.crlf {
+					cw.w.WriteString("\r\n")
+				} else {
+					cw.w.WriteByte('\n')
+				}
+			default:
+				cw.w.WriteRune(r)
+			}
+		}
+		cw.w.WriteByte('"')
+	}
+
+	var err error
+	if cw.crlf {
+		_, err = cw.w.WriteString("\r\n")
+	} else {
+		err = cw.w.WriteByte('\n')
+	}
+	return err
+}
+
+// needsQuotes follows the same rules as encoding/csv
+func (cw *csvWriter) needsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, cw.comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
 }
 
 func addFileCSVLookup() {
@@ -135,9 +278,15 @@ func addFileCSVLookup() {
 		Output:      "[]byte",
 		ContentType: "text/csv",
 		Params: []Param{
-			{Field: "delimiter", Display: "Delimiter", Type: "string", Default: ",", Description: "Separator in between row values"},
-			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Description: "Number of rows"},
+			{Field: "delimiter", Display: "Delimiter", Type: "string", Default: ",", Description: "Separator in between row values, any single character or tab"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of rows"},
 			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function"},
+			{Field: "noheader", Display: "No Header", Type: "bool", Default: "false", Description: "Whether or not to skip the header row"},
+			{Field: "quoteall", Display: "Quote All", Type: "bool", Default: "false", Description: "Whether or not to quote every value"},
+			{Field: "crlf", Display: "CRLF", Type: "bool", Default: "false", Description: "Whether or not to end lines with \\r\\n"},
+			{Field: "bom", Display: "BOM", Type: "bool", Default: "false", Description: "Whether or not to start with a UTF-8 byte order mark"},
+			{Field: "null", Display: "Null", Type: "string", Optional: true, Description: "Output for null values, ex: \\N or NULL"},
+			{Field: "timelayout", Display: "Time Layout", Type: "string", Optional: true, Description: "Go time layout for time values, ex: 2006-01-02"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			co := CSVOptions{}
@@ -154,6 +303,33 @@ func addFileCSVLookup() {
 			}
 			co.RowCount = rowcount
 
+			noHeader, err := info.GetBool(m, "noheader")
+			if err != nil {
+				return nil, err
+			}
+			co.NoHeader = noHeader
+
+			quoteAll, err := info.GetBool(m, "quoteall")
+			if err != nil {
+				return nil, err
+			}
+			co.QuoteAll = quoteAll
+
+			crlf, err := info.GetBool(m, "crlf")
+			if err != nil {
+				return nil, err
+			}
+			co.CRLF = crlf
+
+			bom, err := info.GetBool(m, "bom")
+			if err != nil {
+				return nil, err
+			}
+			co.BOM = bom
+
+			co.Null, _ = info.GetString(m, "null")
+			co.TimeLayout, _ = info.GetString(m, "timelayout")
+
 			fieldsStr, err := info.GetStringArray(m, "fields")
 			if err != nil {
 				return nil, err
@@ -1,9 +1,14 @@
 package gofakeit
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func ExampleCSV_array() {
@@ -107,6 +112,126 @@ func TestCSVNoOptions(t *testing.T) {
 	}
 }
 
+func TestCSVOptions(t *testing.T) {
+	f := New(11)
+	f.AddFuncLookup("nothing", Info{
+		Output: "string",
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return nil, nil
+		},
+	})
+	f.AddFuncLookup("moment", Info{
+		Output: "time.Time",
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC), nil
+		},
+	})
+
+	fields := []Field{
+		{Name: "id", Function: "autoincrement"},
+		{Name: "note", Function: "{randomstring:[a \"quote\",plain]}"},
+		{Name: "deleted_at", Function: "nothing"},
+		{Name: "created_at", Function: "moment"},
+	}
+
+	tests := []struct {
+		name string
+		co   CSVOptions
+		want string
+	}{
+		{
+			name: "defaults",
+			co:   CSVOptions{RowCount: 1},
+			want: "id,note,deleted_at,created_at\n1,{{note}},,\"\"\"2024-02-03T04:05:06Z\"\"\"\n",
+		},
+		{
+			name: "no header pipe",
+			co:   CSVOptions{RowCount: 1, NoHeader: true, Delimiter: "|", TimeLayout: "2006-01-02"},
+			want: "1|{{note}}||2024-02-03\n",
+		},
+		{
+			name: "quote all null",
+			co:   CSVOptions{RowCount: 1, QuoteAll: true, Null: `\N`, TimeLayout: time.RFC3339},
+			want: "\"id\",\"note\",\"deleted_at\",\"created_at\"\n\"1\",{{quoted}},\\N,\"2024-02-03T04:05:06Z\"\n",
+		},
+		{
+			name: "crlf bom",
+			co:   CSVOptions{RowCount: 1, CRLF: true, BOM: true, Null: "NULL", TimeLayout: "15:04"},
+			want: "\uFEFFid,note,deleted_at,created_at\r\n1,{{note}},NULL,04:05\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		co := tt.co
+		co.Fields = fields
+
+		value, err := f.CSV(&co)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		// The note is either a plain value or one that needs quoting
+		got := string(value)
+		for _, note := range []string{"plain", `"a ""quote"""`} {
+			quoted := note
+			if note == "plain" {
+				quoted = `"plain"`
+			}
+			want := strings.ReplaceAll(tt.want, "{{note}}", note)
+			want = strings.ReplaceAll(want, "{{quoted}}", quoted)
+			if got == want {
+				got = ""
+				break
+			}
+		}
+		if got != "" {
+			t.Errorf("%s: unexpected csv %q", tt.name, value)
+		}
+	}
+}
+
+func TestCSVDelimiter(t *testing.T) {
+	fields := []Field{{Name: "a", Function: "letter"}, {Name: "b", Function: "letter"}}
+
+	for _, delimiter := range []string{",", ";", "tab", "|", "~", "→"} {
+		if _, err := CSV(&CSVOptions{RowCount: 1, Delimiter: delimiter, Fields: fields}); err != nil {
+			t.Errorf("delimiter %q: %v", delimiter, err)
+		}
+	}
+
+	for _, delimiter := range []string{`"`, "\n", "\r", "ab", "\xff"} {
+		if _, err := CSV(&CSVOptions{RowCount: 1, Delimiter: delimiter, Fields: fields}); err == nil {
+			t.Errorf("delimiter %q: expected error", delimiter)
+		}
+	}
+}
+
+func TestCSVLookupOptions(t *testing.T) {
+	info := GetFuncLookup("csv")
+
+	m := MapParams{
+		"rowcount":  {"2"},
+		"delimiter": {"|"},
+		"noheader":  {"true"},
+		"quoteall":  {"true"},
+		"crlf":      {"true"},
+		"fields":    {`{"name":"id","function":"autoincrement"}`, `{"name":"code","function":"###"}`},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(value.([]byte)), "\r\n")
+	if len(lines) != 3 || lines[2] != "" {
+		t.Fatalf("expected 2 crlf lines, got %q", value)
+	}
+	if !strings.HasPrefix(lines[0], `"1"|"`) || !strings.HasPrefix(lines[1], `"2"|"`) {
+		t.Errorf("unexpected lines %q", lines)
+	}
+}
+
 func BenchmarkCSVLookup100(b *testing.B) {
 	faker := New(0)
 
@@ -129,3 +254,65 @@ func BenchmarkCSVLookup100(b *testing.B) {
 		}
 	}
 }
+
+func ExampleFaker_WriteCSV() {
+	f := New(11)
+
+	err := f.WriteCSV(context.Background(), os.Stdout, &CSVOptions{
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "last_name", Function: "lastname"},
+		},
+	}, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: id,first_name,last_name
+	// 1,Sonny,Stiedemann
+	// 2,Cody,Donnelly
+	// 3,Julius,Farrell
+}
+
+func TestWriteCSV(t *testing.T) {
+	co := &CSVOptions{
+		RowCount: 50,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "name", Function: "name"},
+			{Name: "address", Function: "address"},
+		},
+	}
+
+	// Streaming output matches the in memory output
+	want, err := New(11).CSV(co)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := New(11).WriteCSV(context.Background(), &b, co, nil); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(want) {
+		t.Errorf("expected streamed csv to match CSV\n%s\n%s", b.String(), want)
+	}
+}
+
+func BenchmarkWriteCSV(b *testing.B) {
+	f := New(11)
+	co := &CSVOptions{
+		RowCount: 1000,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "name", Function: "name"},
+			{Name: "email", Function: "email"},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		f.WriteCSV(context.Background(), io.Discard, co, nil)
+	}
+}
@@ -0,0 +1,538 @@
+package gofakeit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// DatasetOptions defines related tables that are generated together
+type DatasetOptions struct {
+	Tables []DatasetTable `json:"tables" xml:"tables" fake:"skip"` // Tables to generate, in any order
+}
+
+// DatasetTable defines the fields of a table and the parent tables it references
+type DatasetTable struct {
+	Name       string             `json:"name" xml:"name"`             // Table name
+	Count      int                `json:"count" xml:"count"`           // Number of rows, unless a reference sets rows per parent
+	Fields     []Field            `json:"fields" xml:"fields"`         // The fields to be generated
+	References []DatasetReference `json:"references" xml:"references"` // Foreign keys to parent tables
+}
+
+// DatasetReference sets a column to the key of a row in a parent table
+type DatasetReference struct {
+	Field    string  `json:"field" xml:"field"`         // Column set to the parent key, added after the fields if it isnt one
+	Table    string  `json:"table" xml:"table"`         // Parent table name
+	Column   string  `json:"column" xml:"column"`       // Parent column, defaults to the parents first column
+	Count    int     `json:"count" xml:"count"`         // Rows per parent row, replaces the table count
+	CountMax int     `json:"count_max" xml:"count_max"` // Max rows per parent row, picked between count and count max
+	Skew     float64 `json:"skew" xml:"skew"`           // 0 picks parent rows uniformly, higher values favor earlier rows
+}
+
+// DatasetResult is the generated tables in the order they were generated, parents before children
+type DatasetResult struct {
+	Tables []*DatasetRows `json:"tables" xml:"tables"`
+
+	faker *Faker // Faker that generated the tables, used to look up field groups when writing
+}
+
+// DatasetRows is the generated rows of a single table
+type DatasetRows struct {
+	Name    string   `json:"name" xml:"name"`
+	Columns []string `json:"columns" xml:"columns"`
+	Rows    [][]any  `json:"rows" xml:"rows"`
+
+	fields      []Field            // Column fields used by sql, csv and xml
+	outputs     []string           // Output type of each column used for create table
+	references  []DatasetReference // References used for foreign keys
+	parentKeys  []bool             // Whether each reference points to a parent primary key
+	columnIndex map[string]int
+}
+
+// Dataset will generate related tables where references pick keys from already generated parent rows
+func Dataset(do *DatasetOptions) (*DatasetResult, error) { return datasetFunc(GlobalFaker, do) }
+
+// Dataset will generate related tables where references pick keys from already generated parent rows
+func (f *Faker) Dataset(do *DatasetOptions) (*DatasetResult, error) { return datasetFunc(f, do) }
+
+func datasetFunc(f *Faker, do *DatasetOptions) (*DatasetResult, error) {
+	if do == nil || len(do.Tables) == 0 {
+		return nil, errors.New("must pass tables in order to generate a dataset")
+	}
+
+	order, err := datasetOrder(do.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DatasetResult{faker: f}
+	for _, table := range order {
+		rows, err := f.datasetTable(table, result)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Tables = append(result.Tables, rows)
+	}
+
+	return result, nil
+}
+
+// Table will return the rows of a table or nil if it doesnt exist
+func (d *DatasetResult) Table(name string) *DatasetRows {
+	for _, rows := range d.Tables {
+		if rows.Name == name {
+			return rows
+		}
+	}
+
+	return nil
+}
+
+// datasetOrder will sort tables so parents come before the tables that reference them,
+// otherwise keeping the order they were passed in
+func datasetOrder(tables []DatasetTable) ([]*DatasetTable, error) {
+	index := make(map[string]int, len(tables))
+	for i, table := range tables {
+		if table.Name == "" {
+			return nil, errors.New("must provide a name for every table")
+		}
+		if _, ok := index[table.Name]; ok {
+			return nil, fmt.Errorf("table %s is defined more than once", table.Name)
+		}
+		index[table.Name] = i
+	}
+
+	for _, table := range tables {
+		for _, ref := range table.References {
+			if _, ok := index[ref.Table]; !ok {
+				return nil, fmt.Errorf("table %s references table %s which does not exist", table.Name, ref.Table)
+			}
+		}
+	}
+
+	done := make([]bool, len(tables))
+	order := make([]*DatasetTable, 0, len(tables))
+	for len(order) < len(tables) {
+		added := false
+		for i := range tables {
+			if done[i] {
+				continue
+			}
+
+			// Wait until every parent has been added
+			ready := true
+			for _, ref := range tables[i].References {
+				if !done[index[ref.Table]] {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+
+			done[i] = true
+			order = append(order, &tables[i])
+			added = true
+			break
+		}
+
+		if !added {
+			return nil, errors.New("tables have a circular reference")
+		}
+	}
+
+	return order, nil
+}
+
+// datasetTable will generate the rows of a table using the already generated parent tables
+func (f *Faker) datasetTable(table *DatasetTable, result *DatasetResult) (*DatasetRows, error) {
+	if len(table.Fields) == 0 && len(table.References) == 0 {
+		return nil, fmt.Errorf("table %s must provide fields or references", table.Name)
+	}
+
+	rows := &DatasetRows{Name: table.Name, columnIndex: make(map[string]int)}
+	for i := range table.Fields {
+		rows.addColumn(table.Fields[i], sqlFieldOutput(f, &table.Fields[i]))
+	}
+
+	// Resolve each reference to its column and the parent column it copies
+	type resolved struct {
+		column       int
+		parent       *DatasetRows
+		parentColumn int
+		skew         float64
+	}
+	refs := make([]resolved, len(table.References))
+	perParent := -1
+	for i, ref := range table.References {
+		if ref.Field == "" {
+			return nil, fmt.Errorf("table %s must provide a field for every reference", table.Name)
+		}
+		if ref.Count < 0 || ref.CountMax < 0 || ref.Skew < 0 {
+			return nil, fmt.Errorf("table %s reference %s count and skew must be positive", table.Name, ref.Field)
+		}
+
+		parent := result.Table(ref.Table)
+		column := ref.Column
+		if column == "" {
+			if len(parent.Columns) == 0 {
+				return nil, fmt.Errorf("table %s references table %s which has no columns", table.Name, ref.Table)
+			}
+			column = parent.Columns[0]
+		}
+		parentColumn, ok := parent.columnIndex[column]
+		if !ok {
+			return nil, fmt.Errorf("table %s references column %s.%s which does not exist", table.Name, ref.Table, column)
+		}
+
+		if _, ok := rows.columnIndex[ref.Field]; !ok {
+			rows.addColumn(Field{Name: ref.Field}, parent.outputs[parentColumn])
+		}
+
+		if ref.Count > 0 || ref.CountMax > 0 {
+			if perParent >= 0 {
+				return nil, fmt.Errorf("table %s can only set rows per parent on one reference", table.Name)
+			}
+			perParent = i
+		}
+
+		refs[i] = resolved{column: rows.columnIndex[ref.Field], parent: parent, parentColumn: parentColumn, skew: ref.Skew}
+		rows.references = append(rows.references, DatasetReference{Field: ref.Field, Table: ref.Table, Column: column})
+		rows.parentKeys = append(rows.parentKeys, parent.fields[parentColumn].Function == "autoincrement")
+	}
+
+	// Rows per parent repeat each parent row index, otherwise use the table count
+	var owners []int
+	count := table.Count
+	if perParent >= 0 {
+		ref := table.References[perParent]
+		for p := range refs[perParent].parent.Rows {
+			n := ref.Count
+			if ref.CountMax > ref.Count {
+				n = number(f, ref.Count, ref.CountMax)
+			}
+			for ii := 0; ii < n; ii++ {
+				owners = append(owners, p)
+			}
+		}
+		count = len(owners)
+	} else if count <= 0 {
+		return nil, fmt.Errorf("table %s must have a count or rows per parent", table.Name)
+	}
+
+	// Reference columns are not generated from their field
+	isRef := make([]bool, len(rows.Columns))
+	for _, ref := range refs {
+		isRef[ref.column] = true
+	}
+
+	rows.Rows = make([][]any, count)
+	for i := range rows.Rows {
+		row := make([]any, len(rows.Columns))
+		for ii := range table.Fields {
+			if isRef[ii] {
+				continue
+			}
+
+			value, err := f.fieldValue(&table.Fields[ii], i+1, 0, nil)
+			if err != nil {
+				return nil, err
+			}
+			row[ii] = value
+		}
+
+		for ii, ref := range refs {
+			if len(ref.parent.Rows) == 0 {
+				return nil, fmt.Errorf("table %s references table %s which has no rows", table.Name, ref.parent.Name)
+			}
+
+			var pick int
+			if ii == perParent {
+				pick = owners[i]
+			} else {
+				pick = datasetPick(f, len(ref.parent.Rows), ref.skew)
+			}
+			row[ref.column] = ref.parent.Rows[pick][ref.parentColumn]
+		}
+
+		rows.Rows[i] = row
+	}
+
+	return rows, nil
+}
+
+func (rows *DatasetRows) addColumn(field Field, output string) {
+	rows.columnIndex[field.Name] = len(rows.Columns)
+	rows.Columns = append(rows.Columns, field.Name)
+	rows.fields = append(rows.fields, field)
+	rows.outputs = append(rows.outputs, output)
+}
+
+// datasetPick will pick a parent row index, uniformly when skew is 0
+// and increasingly favoring earlier rows as skew grows
+func datasetPick(f *Faker, n int, skew float64) int {
+	if skew <= 0 {
+		return f.IntN(n)
+	}
+
+	i := int(float64(n) * math.Pow(f.Float64(), 1+skew))
+	if i >= n {
+		i = n - 1
+	}
+
+	return i
+}
+
+// SQL will return the insert statements of every table, parents first. Table, Count and Fields
+// are set from each table and CreateTable adds foreign keys for references to a parent primary key
+func (d *DatasetResult) SQL(so *SQLOptions) (string, error) {
+	var sb strings.Builder
+	err := d.WriteSQL(&sb, so)
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// WriteSQL will write the insert statements of every table to w, parents first
+func (d *DatasetResult) WriteSQL(w io.Writer, so *SQLOptions) error {
+	if so == nil {
+		so = &SQLOptions{}
+	}
+
+	dialect, err := sqlDialect(so)
+	if err != nil {
+		return err
+	}
+
+	written := false
+	for _, rows := range d.Tables {
+		if len(rows.Rows) == 0 && !so.CreateTable {
+			continue
+		}
+
+		tso := *so
+		tso.Table = rows.Name
+		tso.Count = len(rows.Rows)
+		tso.Fields = rows.fields
+
+		var create string
+		if so.CreateTable {
+			var keys []string
+			for i, ref := range rows.references {
+				if rows.parentKeys[i] {
+					keys = append(keys, "FOREIGN KEY ("+sqlIdent(dialect, ref.Field)+") REFERENCES "+
+						sqlIdent(dialect, ref.Table)+" ("+sqlIdent(dialect, ref.Column)+")")
+				}
+			}
+			create = sqlCreateTable(dialect, &tso, rows.outputs, keys)
+		}
+
+		if written {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		written = true
+
+		err := sqlWriteRows(context.Background(), w, dialect, &tso, create, func(row int, col int) (any, string, error) {
+			return rows.Rows[row][col], rows.outputs[col], nil
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CSV will return the csv of every table keyed by table name. RowCount and Fields are set from each table
+func (d *DatasetResult) CSV(co *CSVOptions) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(d.Tables))
+	for _, rows := range d.Tables {
+		var sb strings.Builder
+		err := d.WriteCSV(&sb, rows.Name, co)
+		if err != nil {
+			return nil, err
+		}
+
+		files[rows.Name] = []byte(sb.String())
+	}
+
+	return files, nil
+}
+
+// WriteCSV will write the csv of a single table to w
+func (d *DatasetResult) WriteCSV(w io.Writer, table string, co *CSVOptions) error {
+	rows := d.Table(table)
+	if rows == nil {
+		return fmt.Errorf("table %s does not exist", table)
+	}
+
+	tco := CSVOptions{}
+	if co != nil {
+		tco = *co
+	}
+	tco.RowCount = len(rows.Rows)
+	tco.Fields = rows.fields
+
+	return csvWriteRows(context.Background(), w, &tco, func(row int, col int) (any, error) {
+		return rows.Rows[row][col], nil
+	}, nil)
+}
+
+// XML will return the xml of every table keyed by table name. RootElement defaults to the
+// table name, RecordElement defaults to record and RowCount and Fields are set from each table
+func (d *DatasetResult) XML(xo *XMLOptions) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(d.Tables))
+	for _, rows := range d.Tables {
+		var sb strings.Builder
+		err := d.WriteXML(&sb, rows.Name, xo)
+		if err != nil {
+			return nil, err
+		}
+
+		files[rows.Name] = []byte(sb.String())
+	}
+
+	return files, nil
+}
+
+// WriteXML will write the xml of a single table to w
+func (d *DatasetResult) WriteXML(w io.Writer, table string, xo *XMLOptions) error {
+	rows := d.Table(table)
+	if rows == nil {
+		return fmt.Errorf("table %s does not exist", table)
+	}
+
+	txo := XMLOptions{}
+	if xo != nil {
+		txo = *xo
+	}
+	txo.Type = "array"
+	txo.RowCount = len(rows.Rows)
+	txo.Fields = rows.fields
+	if txo.RootElement == "" {
+		txo.RootElement = rows.Name
+	}
+	if txo.RecordElement == "" {
+		txo.RecordElement = "record"
+	}
+
+	f := d.faker
+	if f == nil {
+		f = GlobalFaker
+	}
+
+	return xmlWriteRows(context.Background(), f, w, &txo, func(i int) (jsonOrderedKeyVal, error) {
+		obj := make(jsonOrderedKeyVal, len(rows.Columns))
+		for ii, value := range rows.Rows[i-1] {
+			obj[ii] = &jsonKeyVal{Key: rows.Columns[ii], Value: value}
+		}
+		return obj, nil
+	}, nil)
+}
+
+// JSON will return an object with an array of row objects for every table, keyed by table name
+func (d *DatasetResult) JSON(indent bool) ([]byte, error) {
+	obj := make(jsonOrderedKeyVal, len(d.Tables))
+	for i, rows := range d.Tables {
+		items := make([]any, len(rows.Rows))
+		for ii, row := range rows.Rows {
+			item := make(jsonOrderedKeyVal, len(rows.Columns))
+			for iii, value := range row {
+				value, err := jsonLeafValue(value)
+				if err != nil {
+					return nil, err
+				}
+				item[iii] = &jsonKeyVal{Key: rows.Columns[iii], Value: value}
+			}
+			items[ii] = item
+		}
+
+		obj[i] = &jsonKeyVal{Key: rows.Name, Value: items}
+	}
+
+	if indent {
+		return json.MarshalIndent(obj, "", "    ")
+	}
+
+	return json.Marshal(obj)
+}
+
+func addFileDatasetLookup() {
+	AddFuncLookup("dataset", Info{
+		Display:     "Dataset",
+		Category:    "file",
+		Description: "Related tables where foreign keys reference rows of already generated parent tables",
+		Example: `{
+			"users": [{ "id": 1, "name": "Markus Moen" }, { "id": 2, "name": "Osborne Hilll" }],
+			"orders": [{ "id": 1, "total": 32.13, "user_id": 2 }, { "id": 2, "total": 8.99, "user_id": 2 }]
+		}`,
+		Output:      "[]byte",
+		ContentType: "application/json",
+		Params: []Param{
+			{
+				Field:   "tables",
+				Display: "Tables",
+				Type:    "[]DatasetTable",
+				Default: `[{"name":"users","count":10,"fields":[{"name":"id","function":"autoincrement"},{"name":"name","function":"name"}]}` +
+					`,{"name":"orders","fields":[{"name":"id","function":"autoincrement"},{"name":"total","function":"price"}],` +
+					`"references":[{"field":"user_id","table":"users","count":0,"count_max":3}]}]`,
+				Description: "Tables containing a name, count, fields and references to parent tables in json format",
+			},
+			{Field: "format", Display: "Format", Type: "string", Default: "json", Options: []string{"json", "sql"}, Description: "Output format of the dataset"},
+			{Field: "dialect", Display: "Dialect", Type: "string", Optional: true, Options: []string{"postgres", "mysql", "sqlite", "sqlserver"}, Description: "SQL dialect used for the sql format"},
+			{Field: "indent", Display: "Indent", Type: "bool", Default: "false", Description: "Whether or not to add indents and newlines to the json format"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			tablesStr, err := info.GetStringArray(m, "tables")
+			if err != nil {
+				return nil, err
+			}
+
+			// Tables are passed as a single json array or one table per value
+			tablesJSON := strings.TrimSpace(strings.Join(tablesStr, ","))
+			if !strings.HasPrefix(tablesJSON, "[") {
+				tablesJSON = "[" + tablesJSON + "]"
+			}
+
+			do := DatasetOptions{}
+			err = json.Unmarshal([]byte(tablesJSON), &do.Tables)
+			if err != nil {
+				return nil, err
+			}
+
+			format, err := info.GetString(m, "format")
+			if err != nil {
+				return nil, err
+			}
+
+			dialect, _ := info.GetString(m, "dialect")
+
+			indent, err := info.GetBool(m, "indent")
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := datasetFunc(f, &do)
+			if err != nil {
+				return nil, err
+			}
+
+			if format == "sql" {
+				value, err := result.SQL(&SQLOptions{Dialect: dialect})
+				return []byte(value), err
+			}
+
+			return result.JSON(indent)
+		},
+	})
+}
@@ -0,0 +1,340 @@
+package gofakeit
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleDataset() {
+	f := New(11)
+
+	result, err := f.Dataset(&DatasetOptions{
+		Tables: []DatasetTable{
+			{
+				Name: "orders",
+				Fields: []Field{
+					{Name: "id", Function: "autoincrement"},
+					{Name: "total", Function: "price", Params: MapParams{"min": {"5"}, "max": {"50"}}},
+				},
+				References: []DatasetReference{{Field: "user_id", Table: "users", Count: 1, CountMax: 3}},
+			},
+			{
+				Name:  "users",
+				Count: 2,
+				Fields: []Field{
+					{Name: "id", Function: "autoincrement"},
+					{Name: "name", Function: "firstname"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	value, err := result.JSON(false)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output: {"users":[{"id":1,"name":"Sonny"},{"id":2,"name":"Russ"}],"orders":[{"id":1,"total":18.98,"user_id":1},{"id":2,"total":45.91,"user_id":2}]}
+}
+
+func datasetTestTables() []DatasetTable {
+	return []DatasetTable{
+		{
+			Name: "order_items",
+			Fields: []Field{
+				{Name: "id", Function: "autoincrement"},
+				{Name: "product_id", Function: "autoincrement"},
+				{Name: "quantity", Function: "number", Params: MapParams{"min": {"1"}, "max": {"5"}}},
+			},
+			References: []DatasetReference{
+				{Field: "order_id", Table: "orders", Count: 1, CountMax: 3},
+				{Field: "product_id", Table: "products", Skew: 2},
+			},
+		},
+		{
+			Name: "orders",
+			Fields: []Field{
+				{Name: "id", Function: "autoincrement"},
+				{Name: "created_at", Function: "pastdate"},
+			},
+			References: []DatasetReference{{Field: "user_id", Table: "users", Column: "id"}},
+			Count:      50,
+		},
+		{
+			Name:   "users",
+			Count:  10,
+			Fields: []Field{{Name: "id", Function: "autoincrement"}, {Name: "email", Function: "email"}},
+		},
+		{
+			Name:   "products",
+			Count:  20,
+			Fields: []Field{{Name: "id", Function: "autoincrement"}, {Name: "name", Function: "productname"}},
+		},
+	}
+}
+
+func TestDataset(t *testing.T) {
+	result, err := New(11).Dataset(&DatasetOptions{Tables: datasetTestTables()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Parents are generated before the tables that reference them
+	var names []string
+	for _, rows := range result.Tables {
+		names = append(names, rows.Name)
+	}
+	if strings.Join(names, ",") != "users,orders,products,order_items" {
+		t.Fatalf("unexpected table order %v", names)
+	}
+
+	users, orders, items := result.Table("users"), result.Table("orders"), result.Table("order_items")
+	if len(users.Rows) != 10 || len(orders.Rows) != 50 {
+		t.Fatalf("expected 10 users and 50 orders, got %d and %d", len(users.Rows), len(orders.Rows))
+	}
+
+	// Reference columns not in the fields are added at the end
+	if strings.Join(orders.Columns, ",") != "id,created_at,user_id" {
+		t.Errorf("unexpected order columns %v", orders.Columns)
+	}
+	if strings.Join(items.Columns, ",") != "id,product_id,quantity,order_id" {
+		t.Errorf("unexpected item columns %v", items.Columns)
+	}
+
+	for _, order := range orders.Rows {
+		userID := order[2].(int)
+		if userID < 1 || userID > 10 {
+			t.Errorf("order references user %d which does not exist", userID)
+		}
+	}
+
+	// Every order has 1 to 3 items, grouped in order
+	perOrder := map[int]int{}
+	last := 0
+	for _, item := range items.Rows {
+		orderID := item[3].(int)
+		if orderID < last {
+			t.Errorf("expected items grouped by order, got %d after %d", orderID, last)
+		}
+		last = orderID
+		perOrder[orderID]++
+
+		productID := item[1].(int)
+		if productID < 1 || productID > 20 {
+			t.Errorf("item references product %d which does not exist", productID)
+		}
+	}
+	if len(perOrder) != 50 {
+		t.Errorf("expected items for 50 orders, got %d", len(perOrder))
+	}
+	for orderID, count := range perOrder {
+		if count < 1 || count > 3 {
+			t.Errorf("expected 1 to 3 items for order %d, got %d", orderID, count)
+		}
+	}
+}
+
+func TestDatasetSkew(t *testing.T) {
+	tables := []DatasetTable{
+		{Name: "users", Count: 100, Fields: []Field{{Name: "id", Function: "autoincrement"}}},
+		{Name: "uniform", Count: 10000, Fields: []Field{{Name: "id", Function: "autoincrement"}}, References: []DatasetReference{{Field: "user_id", Table: "users"}}},
+		{Name: "skewed", Count: 10000, Fields: []Field{{Name: "id", Function: "autoincrement"}}, References: []DatasetReference{{Field: "user_id", Table: "users", Skew: 3}}},
+	}
+
+	result, err := New(11).Dataset(&DatasetOptions{Tables: tables})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count how many rows reference the first 10 users
+	firstTen := func(name string) int {
+		count := 0
+		for _, row := range result.Table(name).Rows {
+			if row[1].(int) <= 10 {
+				count++
+			}
+		}
+		return count
+	}
+
+	uniform, skewed := firstTen("uniform"), firstTen("skewed")
+	if uniform < 800 || uniform > 1200 {
+		t.Errorf("expected about 1000 uniform rows for the first 10 users, got %d", uniform)
+	}
+	if skewed < 4000 {
+		t.Errorf("expected most skewed rows for the first 10 users, got %d", skewed)
+	}
+}
+
+func TestDatasetSQL(t *testing.T) {
+	result, err := New(11).Dataset(&DatasetOptions{Tables: []DatasetTable{
+		{Name: "users", Count: 2, Fields: []Field{{Name: "id", Function: "autoincrement"}, {Name: "active", Value: true}}},
+		{Name: "orders", Count: 2, Fields: []Field{{Name: "id", Function: "autoincrement"}}, References: []DatasetReference{{Field: "user_id", Table: "users"}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := result.SQL(&SQLOptions{Dialect: "postgres", CreateTable: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `CREATE TABLE "users" (
+	"id" INTEGER NOT NULL,
+	"active" BOOLEAN NOT NULL,
+	PRIMARY KEY ("id")
+);
+INSERT INTO "users" ("id", "active") VALUES (1, TRUE),(2, TRUE);
+CREATE TABLE "orders" (
+	"id" INTEGER NOT NULL,
+	"user_id" INTEGER NOT NULL,
+	PRIMARY KEY ("id"),
+	FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+);
+INSERT INTO "orders" ("id", "user_id") VALUES (1, `
+	if !strings.HasPrefix(value, want) {
+		t.Errorf("unexpected sql\ngot  %s\nwant %s", value, want)
+	}
+}
+
+func TestDatasetCSV(t *testing.T) {
+	result, err := New(11).Dataset(&DatasetOptions{Tables: datasetTestTables()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := result.CSV(&CSVOptions{Delimiter: ";"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 4 {
+		t.Fatalf("expected 4 files, got %d", len(files))
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(files["orders"])), "\n")
+	if len(lines) != 51 || lines[0] != "id;created_at;user_id" {
+		t.Errorf("unexpected orders csv %s", files["orders"])
+	}
+
+	if err := result.WriteCSV(&strings.Builder{}, "notatable", nil); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
+
+func TestDatasetJSON(t *testing.T) {
+	result, err := New(11).Dataset(&DatasetOptions{Tables: datasetTestTables()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := result.JSON(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tables map[string][]map[string]any
+	if err := json.Unmarshal(value, &tables); err != nil {
+		t.Fatal(err)
+	}
+	if len(tables["users"]) != 10 || len(tables["orders"]) != 50 {
+		t.Errorf("unexpected json tables %v", tables)
+	}
+}
+
+func TestDatasetErrors(t *testing.T) {
+	users := DatasetTable{Name: "users", Count: 1, Fields: []Field{{Name: "id", Function: "autoincrement"}}}
+
+	tests := map[string][]DatasetTable{
+		"no tables":      {},
+		"no name":        {{Count: 1, Fields: users.Fields}},
+		"duplicate":      {users, users},
+		"missing table":  {{Name: "orders", Count: 1, References: []DatasetReference{{Field: "user_id", Table: "notatable"}}}},
+		"missing column": {users, {Name: "orders", Count: 1, References: []DatasetReference{{Field: "user_id", Table: "users", Column: "nope"}}}},
+		"missing field":  {users, {Name: "orders", Count: 1, References: []DatasetReference{{Table: "users"}}}},
+		"circular":       {{Name: "a", Count: 1, References: []DatasetReference{{Field: "b_id", Table: "b"}}}, {Name: "b", Count: 1, References: []DatasetReference{{Field: "a_id", Table: "a"}}}},
+		"no count":       {{Name: "users", Fields: users.Fields}},
+		"negative count": {users, {Name: "orders", References: []DatasetReference{{Field: "user_id", Table: "users", Count: -1}}}},
+		"two per parent": {users, {Name: "orders", References: []DatasetReference{{Field: "a", Table: "users", Count: 1}, {Field: "b", Table: "users", Count: 1}}}},
+		"no fields":      {{Name: "users", Count: 1}, {Name: "orders", Count: 1, References: []DatasetReference{{Field: "user_id", Table: "users"}}}},
+		"invalid field":  {{Name: "users", Count: 1, Fields: []Field{{Name: "id", Function: "notafunction"}}}},
+		"negative skew":  {{Name: "x", Count: 1, Fields: users.Fields}, {Name: "users", Count: 1, Fields: users.Fields, References: []DatasetReference{{Field: "x_id", Table: "x", Skew: -1}}}},
+	}
+
+	for name, tables := range tests {
+		_, err := New(11).Dataset(&DatasetOptions{Tables: tables})
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDatasetLookup(t *testing.T) {
+	info := GetFuncLookup("dataset")
+
+	m := MapParams{
+		"tables": {
+			`{"name":"users","count":3,"fields":[{"name":"id","function":"autoincrement"}]}`,
+			`{"name":"orders","count":5,"fields":[{"name":"id","function":"autoincrement"}],"references":[{"field":"user_id","table":"users"}]}`,
+		},
+		"format":  {"sql"},
+		"dialect": {"mysql"},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql := string(value.([]byte))
+	if !strings.HasPrefix(sql, "INSERT INTO `users` (`id`) VALUES (1),(2),(3);\nINSERT INTO `orders` (`id`, `user_id`) VALUES (1, ") {
+		t.Errorf("unexpected sql %s", sql)
+	}
+}
+
+func BenchmarkDataset(b *testing.B) {
+	f := New(11)
+	do := &DatasetOptions{Tables: datasetTestTables()}
+
+	for i := 0; i < b.N; i++ {
+		f.Dataset(do)
+	}
+}
+
+func TestDatasetXML(t *testing.T) {
+	result, err := New(11).Dataset(&DatasetOptions{Tables: datasetTestTables()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := result.XML(&XMLOptions{RecordElement: "row"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var users struct {
+		XMLName xml.Name `xml:"users"`
+		Rows    []struct {
+			ID    int    `xml:"id"`
+			Email string `xml:"email"`
+		} `xml:"row"`
+	}
+	if err := xml.Unmarshal(files["users"], &users); err != nil {
+		t.Fatal(err)
+	}
+	if len(users.Rows) != 10 || users.Rows[9].ID != 10 || users.Rows[0].Email == "" {
+		t.Errorf("unexpected users xml %s", files["users"])
+	}
+
+	if err := result.WriteXML(&strings.Builder{}, "notatable", nil); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
@@ -19,6 +19,19 @@ type Faker struct {
 	// Lock to make thread safe
 	Locked bool
 	mu     sync.Mutex
+
+	// Function lookups layered on top of the global FuncLookups.
+	// A nil entry marks a function as removed for this faker
+	funcLookups map[string]*Info
+
+	// Middleware wrapped around every lookup generate call
+	middleware []func(next GenerateFunc) GenerateFunc
+
+	// Field groups layered on top of the global FieldGroups
+	fieldGroups map[string][]Field
+
+	// Lock for funcLookups, middleware and fieldGroups
+	funcLookupsLock sync.RWMutex
 }
 
 // New creates and returns a new Faker struct seeded with a given seed
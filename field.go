@@ -0,0 +1,176 @@
+package gofakeit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FieldGroups is the map of all named field groups that can be nested with Field.Ref
+var FieldGroups map[string][]Field
+var lockFieldGroups sync.RWMutex
+
+// fieldMaxDepth limits how deep fields can nest, which stops refs that reference themselves
+const fieldMaxDepth = 16
+
+// AddFieldGroup will add a named group of fields that can be nested with Field.Ref
+// Ex: AddFieldGroup("address", []Field{{Name: "city", Function: "city"}})
+func AddFieldGroup(name string, fields []Field) {
+	lockFieldGroups.Lock()
+	if FieldGroups == nil {
+		FieldGroups = make(map[string][]Field)
+	}
+	FieldGroups[name] = fields
+	lockFieldGroups.Unlock()
+}
+
+// GetFieldGroup will return the fields for the given group name or nil if it doesnt exist
+func GetFieldGroup(name string) []Field {
+	lockFieldGroups.RLock()
+	defer lockFieldGroups.RUnlock()
+
+	return FieldGroups[name]
+}
+
+// RemoveFieldGroup will remove a field group
+func RemoveFieldGroup(name string) {
+	lockFieldGroups.Lock()
+	delete(FieldGroups, name)
+	lockFieldGroups.Unlock()
+}
+
+// AddFieldGroup will add a named group of fields for this faker only,
+// taking priority over a global group of the same name
+func (f *Faker) AddFieldGroup(name string, fields []Field) {
+	f.funcLookupsLock.Lock()
+	if f.fieldGroups == nil {
+		f.fieldGroups = make(map[string][]Field)
+	}
+	f.fieldGroups[name] = fields
+	f.funcLookupsLock.Unlock()
+}
+
+// GetFieldGroup will return the fields for the given group name by checking
+// the fakers own groups first and then falling back to the global FieldGroups
+func (f *Faker) GetFieldGroup(name string) []Field {
+	f.funcLookupsLock.RLock()
+	fields, ok := f.fieldGroups[name]
+	f.funcLookupsLock.RUnlock()
+	if ok {
+		return fields
+	}
+
+	return GetFieldGroup(name)
+}
+
+// RemoveFieldGroup will remove a field group added to this faker.
+// The global FieldGroups are left untouched
+func (f *Faker) RemoveFieldGroup(name string) {
+	f.funcLookupsLock.Lock()
+	delete(f.fieldGroups, name)
+	f.funcLookupsLock.Unlock()
+}
+
+// fieldObject will generate each field in order, recursing into child fields and arrays.
+// Index is the current row number used by autoincrement and leaf, if set,
+// is run on every function value so each format can convert values as needed
+func (f *Faker) fieldObject(fields []Field, index int, depth int, leaf func(value any) (any, error)) (jsonOrderedKeyVal, error) {
+	if depth > fieldMaxDepth {
+		return nil, errors.New("fields are nested too deep, check for refs that reference themselves")
+	}
+
+	obj := make(jsonOrderedKeyVal, len(fields))
+	for i := range fields {
+		value, err := f.fieldValue(&fields[i], index, depth, leaf)
+		if err != nil {
+			return nil, err
+		}
+
+		obj[i] = &jsonKeyVal{Key: fields[i].Name, Value: value}
+	}
+
+	return obj, nil
+}
+
+// fieldValue will generate a single field value or an array of values if the field has a count
+func (f *Faker) fieldValue(field *Field, index int, depth int, leaf func(value any) (any, error)) (any, error) {
+	if field.Count == 0 && field.CountMax == 0 {
+		return f.fieldItem(field, index, depth, leaf)
+	}
+
+	count, err := fieldCount(f, field)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, count)
+	for i := range values {
+		// Array items are numbered from 1 for autoincrement
+		values[i], err = f.fieldItem(field, i+1, depth, leaf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// fieldItem will generate a nested object if the field has child fields, otherwise it calls its function
+func (f *Faker) fieldItem(field *Field, index int, depth int, leaf func(value any) (any, error)) (any, error) {
+	children, err := f.fieldChildren(field)
+	if err != nil {
+		return nil, err
+	}
+	if children != nil {
+		return f.fieldObject(children, index, depth+1, leaf)
+	}
+
+	if field.Function == "autoincrement" {
+		return index, nil
+	}
+
+	value, _, err := f.callField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if leaf != nil {
+		return leaf(value)
+	}
+
+	return value, nil
+}
+
+// fieldChildren will return the child fields of a field, looking up its ref if set
+func (f *Faker) fieldChildren(field *Field) ([]Field, error) {
+	if field.Ref == "" {
+		if len(field.Fields) == 0 {
+			return nil, nil
+		}
+		return field.Fields, nil
+	}
+
+	if len(field.Fields) > 0 {
+		return nil, fmt.Errorf("field %s cannot set both fields and ref", field.Name)
+	}
+
+	fields := f.GetFieldGroup(field.Ref)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("field group %s does not exist", field.Ref)
+	}
+
+	return fields, nil
+}
+
+// fieldCount will return the number of array items for a field
+func fieldCount(f *Faker, field *Field) (int, error) {
+	if field.Count < 0 || field.CountMax < 0 {
+		return 0, fmt.Errorf("field %s count must be positive", field.Name)
+	}
+
+	if field.CountMax > field.Count {
+		return number(f, field.Count, field.CountMax), nil
+	}
+
+	return field.Count, nil
+}
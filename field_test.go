@@ -0,0 +1,333 @@
+package gofakeit
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleAddFieldGroup() {
+	f := New(11)
+
+	AddFieldGroup("address", []Field{
+		{Name: "city", Function: "city"},
+		{Name: "zip", Function: "zip"},
+	})
+	defer RemoveFieldGroup("address")
+
+	value, err := f.JSON(&JSONOptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "name", Function: "firstname"},
+			{Name: "address", Ref: "address"},
+			{Name: "items", Count: 2, Fields: []Field{
+				{Name: "id", Function: "autoincrement"},
+				{Name: "price", Function: "price", Params: MapParams{"min": {"1"}, "max": {"10"}}},
+			}},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output: {"name":"Sonny","address":{"city":"Winston-Salem","zip":"25275"},"items":[{"id":1,"price":6.64},{"id":2,"price":9.53}]}
+}
+
+func TestJSONNestedFields(t *testing.T) {
+	value, err := New(11).JSON(&JSONOptions{
+		Type:     "array",
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "user", Fields: []Field{
+				{Name: "last", Function: "lastname"},
+				{Name: "first", Function: "firstname"},
+				{Name: "tags", Function: "word", Count: 3},
+			}},
+			{Name: "items", CountMax: 4, Fields: []Field{
+				{Name: "line", Function: "autoincrement"},
+				{Name: "sku", Function: "###-???"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Key order follows the fields, at every level
+	str := string(value)
+	if !strings.HasPrefix(str, `[{"id":1,"user":{"last":`) {
+		t.Errorf("unexpected key order %s", str)
+	}
+	if strings.Index(str, `"first"`) > strings.Index(str, `"tags"`) {
+		t.Errorf("expected first before tags %s", str)
+	}
+
+	var rows []struct {
+		ID   int `json:"id"`
+		User struct {
+			Last  string   `json:"last"`
+			First string   `json:"first"`
+			Tags  []string `json:"tags"`
+		} `json:"user"`
+		Items []struct {
+			Line int    `json:"line"`
+			SKU  string `json:"sku"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(value, &rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	for i, row := range rows {
+		if row.ID != i+1 {
+			t.Errorf("expected id %d, got %d", i+1, row.ID)
+		}
+		if row.User.First == "" || row.User.Last == "" {
+			t.Errorf("expected nested user, got %+v", row.User)
+		}
+		if len(row.User.Tags) != 3 {
+			t.Errorf("expected 3 tags, got %d", len(row.User.Tags))
+		}
+		if len(row.Items) > 4 {
+			t.Errorf("expected at most 4 items, got %d", len(row.Items))
+		}
+		for ii, item := range row.Items {
+			if item.Line != ii+1 || len(item.SKU) != 7 {
+				t.Errorf("unexpected item %+v", item)
+			}
+		}
+	}
+}
+
+func TestJSONNestedFieldsLookup(t *testing.T) {
+	info := GetFuncLookup("json")
+
+	m := MapParams{
+		"type": {"object"},
+		"fields": {
+			`{"name":"name","function":"firstname"}`,
+			`{"name":"address","fields":[{"name":"street","function":"street"},{"name":"city","function":"city"}]}`,
+			`{"name":"scores","function":"number","params":{"min":["1"],"max":["5"]},"count":2,"count_max":3}`,
+		},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var obj struct {
+		Name    string            `json:"name"`
+		Address map[string]string `json:"address"`
+		Scores  []int             `json:"scores"`
+	}
+	if err := json.Unmarshal(value.([]byte), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Address["street"] == "" || obj.Address["city"] == "" {
+		t.Errorf("expected nested address, got %v", obj.Address)
+	}
+	if len(obj.Scores) < 2 || len(obj.Scores) > 3 {
+		t.Errorf("expected 2 to 3 scores, got %v", obj.Scores)
+	}
+}
+
+func TestXMLNestedFields(t *testing.T) {
+	AddFieldGroup("address", []Field{
+		{Name: "street", Function: "street"},
+		{Name: "city", Function: "city"},
+	})
+	defer RemoveFieldGroup("address")
+
+	value, err := New(11).XML(&XMLOptions{
+		Type:          "array",
+		RootElement:   "orders",
+		RecordElement: "order",
+		RowCount:      2,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "address", Ref: "address"},
+			{Name: "items", Count: 2, Fields: []Field{
+				{Name: "sku", Function: "###"},
+				{Name: "name", Function: "productname"},
+			}},
+			{Name: "tags", Function: "word", Count: 2},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var orders struct {
+		Order []struct {
+			ID      int `xml:"id"`
+			Address struct {
+				Street string `xml:"street"`
+				City   string `xml:"city"`
+			} `xml:"address"`
+			Items []struct {
+				SKU  string `xml:"sku"`
+				Name string `xml:"name"`
+			} `xml:"items>value"`
+			Tags []string `xml:"tags>value"`
+		} `xml:"order"`
+	}
+	if err := xml.Unmarshal(value, &orders); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(orders.Order) != 2 {
+		t.Fatalf("expected 2 orders, got %d: %s", len(orders.Order), value)
+	}
+	for i, order := range orders.Order {
+		if order.ID != i+1 {
+			t.Errorf("expected id %d, got %d", i+1, order.ID)
+		}
+		if order.Address.Street == "" || order.Address.City == "" {
+			t.Errorf("expected nested address, got %+v", order.Address)
+		}
+		if len(order.Items) != 2 || order.Items[0].Name == "" || len(order.Items[0].SKU) != 3 {
+			t.Errorf("expected 2 items, got %+v", order.Items)
+		}
+		if len(order.Tags) != 2 {
+			t.Errorf("expected 2 tags, got %v", order.Tags)
+		}
+	}
+
+	// Nested key order follows the fields
+	str := string(value)
+	if strings.Index(str, "<street>") > strings.Index(str, "<city>") {
+		t.Errorf("expected street before city %s", str)
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	AddFieldGroup("loop", []Field{{Name: "loop", Ref: "loop"}})
+	defer RemoveFieldGroup("loop")
+
+	tests := map[string]Field{
+		"missing ref":    {Name: "a", Ref: "notagroup"},
+		"ref and fields": {Name: "a", Ref: "loop", Fields: []Field{{Name: "b", Function: "city"}}},
+		"self ref":       {Name: "a", Ref: "loop"},
+		"negative count": {Name: "a", Function: "city", Count: -1},
+		"bad child":      {Name: "a", Fields: []Field{{Name: "b", Function: "notafunction"}}},
+	}
+
+	for name, field := range tests {
+		_, err := New(11).JSON(&JSONOptions{Type: "object", Fields: []Field{field}})
+		if err == nil {
+			t.Errorf("%s: expected json error", name)
+		}
+
+		_, err = New(11).XML(&XMLOptions{Type: "single", Fields: []Field{field}})
+		if err == nil {
+			t.Errorf("%s: expected xml error", name)
+		}
+	}
+}
+
+func TestFieldGroup(t *testing.T) {
+	AddFieldGroup("test", []Field{{Name: "city", Function: "city"}})
+	if len(GetFieldGroup("test")) != 1 {
+		t.Fatal("expected field group to be added")
+	}
+
+	RemoveFieldGroup("test")
+	if GetFieldGroup("test") != nil {
+		t.Fatal("expected field group to be removed")
+	}
+}
+
+func TestFakerFieldGroup(t *testing.T) {
+	AddFieldGroup("test", []Field{{Name: "city", Function: "city"}})
+	defer RemoveFieldGroup("test")
+
+	f := New(11)
+	f.AddFieldGroup("test", []Field{{Name: "zip", Function: "zip"}})
+	f.AddFieldGroup("local", []Field{{Name: "state", Function: "state"}})
+	if GetFieldGroup("local") != nil {
+		t.Fatal("expected faker field group to not be added globally")
+	}
+
+	fields := []Field{{Name: "a", Ref: "test"}, {Name: "b", Ref: "local"}}
+	value, err := f.JSON(&JSONOptions{Type: "object", Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(value), `"a":{"zip":`) || !strings.Contains(string(value), `"b":{"state":`) {
+		t.Errorf("expected faker field groups to be used %s", value)
+	}
+
+	value, err = f.XML(&XMLOptions{Type: "single", Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(value), "<zip>") || !strings.Contains(string(value), "<state>") {
+		t.Errorf("expected faker field groups to be used %s", value)
+	}
+
+	// Other fakers only see the global group
+	if _, err := New(11).JSON(&JSONOptions{Type: "object", Fields: fields}); err == nil {
+		t.Error("expected error for a group only added to another faker")
+	}
+
+	f.RemoveFieldGroup("test")
+	if group := f.GetFieldGroup("test"); len(group) != 1 || group[0].Name != "city" {
+		t.Errorf("expected global group after removing faker group, got %v", group)
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	fields := []Field{
+		{Name: "version", Value: "2.0"},
+		{Name: "count", Value: 5},
+	}
+
+	value, err := New(11).JSON(&JSONOptions{Type: "object", Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != `{"version":"2.0","count":5}` {
+		t.Errorf("unexpected json %s", value)
+	}
+
+	sql, err := New(11).SQL(&SQLOptions{Table: "t", Count: 1, Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "INSERT INTO t (version, count) VALUES ('2.0', 5);" {
+		t.Errorf("unexpected sql %s", sql)
+	}
+}
+
+func BenchmarkJSONNestedFields(b *testing.B) {
+	f := New(11)
+	jo := &JSONOptions{
+		Type:     "array",
+		RowCount: 10,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "user", Fields: []Field{
+				{Name: "first", Function: "firstname"},
+				{Name: "last", Function: "lastname"},
+			}},
+			{Name: "items", Count: 3, Fields: []Field{
+				{Name: "sku", Function: "###-???"},
+				{Name: "price", Function: "price"},
+			}},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		f.JSON(jo)
+	}
+}
@@ -82,7 +82,7 @@ func addGameLookup() {
 		Output:      "[]uint",
 		Params: []Param{
 			{Field: "numdice", Display: "Number of Dice", Type: "uint", Default: "1", Description: "Number of dice to roll"},
-			{Field: "sides", Display: "Number of Sides", Type: "[]uint", Default: "[6]", Description: "Number of sides on each dice"},
+			{Field: "sides", Display: "Number of Sides", Type: "[]uint", Default: "[6]", Min: "1", Description: "Number of sides on each dice"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			numDice, err := info.GetUint(m, "numdice")
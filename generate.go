@@ -5,8 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"regexp"
 	"regexp/syntax"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Generate fake information from given string.
@@ -18,10 +23,25 @@ import (
 // Ex: {number:1,10} - 4
 // Ex: {uuid} - 590c1440-9888-45b0-bd51-a817ee07c3f2
 //
+// Transforms
+// Ex: {firstname|lower} - billy
+// Ex: {sentence:5|truncate:10} - Record riv
+// Ex: {number:1,100|pad:5} - 00042
+//
+// Alternatives
+// Ex: {red|green|blue} - green - pick one at random
+// Ex: {active:80|deleted:20} - active - pick one by weight
+// Ex: {firstname}[ {middlename}]? - billy james - optional, 50% of the time
+// Ex: {firstname}[ {middlename}]?20% - billy - optional, 20% of the time
+//
 // Letters/Numbers
 // Ex: ### - 481 - random numbers
 // Ex: ??? - fda - random letters
 //
+// Escapes
+// Ex: \#{number:1,9} - #4 - backslash outputs #, ?, {, }, [, ], | or \ as is
+// Ex: {{firstname}} - {firstname} - doubled brackets output a single bracket
+//
 // For a complete list of runnable functions use FuncsLookup
 func Generate(dataVal string) (string, error) { return generate(GlobalFaker, dataVal) }
 
@@ -34,134 +54,59 @@ func Generate(dataVal string) (string, error) { return generate(GlobalFaker, dat
 // Ex: {number:1,10} - 4
 // Ex: {uuid} - 590c1440-9888-45b0-bd51-a817ee07c3f2
 //
+// Transforms
+// Ex: {firstname|lower} - billy
+// Ex: {sentence:5|truncate:10} - Record riv
+// Ex: {number:1,100|pad:5} - 00042
+//
+// Alternatives
+// Ex: {red|green|blue} - green - pick one at random
+// Ex: {active:80|deleted:20} - active - pick one by weight
+// Ex: {firstname}[ {middlename}]? - billy james - optional, 50% of the time
+// Ex: {firstname}[ {middlename}]?20% - billy - optional, 20% of the time
+//
 // Letters/Numbers
 // Ex: ### - 481 - random numbers
 // Ex: ??? - fda - random letters
 //
+// Escapes
+// Ex: \#{number:1,9} - #4 - backslash outputs #, ?, {, }, [, ], | or \ as is
+// Ex: {{firstname}} - {firstname} - doubled brackets output a single bracket
+//
 // For a complete list of runnable functions use FuncsLookup
 func (f *Faker) Generate(dataVal string) (string, error) { return generate(f, dataVal) }
 
 func generate(f *Faker, dataVal string) (string, error) {
-	// Replace # with numbers and ? with letters
-	dataVal = replaceWithNumbers(f, dataVal)
-	dataVal = replaceWithLetters(f, dataVal)
-
-	// Check if string has any replaceable values
-	// Even if it doesnt its ok we will just return the string
-	if !strings.Contains(dataVal, "{") && !strings.Contains(dataVal, "}") {
-		return dataVal, nil
-	}
-
-	// Variables to identify the index in which it exists
-	startCurly := -1
-	startCurlyIgnore := []int{}
-	endCurly := -1
-	endCurlyIgnore := []int{}
-
-	// Loop through string characters
-	for i := 0; i < len(dataVal); i++ {
-		// Check for ignores if equal skip
-		shouldSkip := false
-		for _, igs := range startCurlyIgnore {
-			if i == igs {
-				shouldSkip = true
-			}
-		}
-		for _, ige := range endCurlyIgnore {
-			if i == ige {
-				shouldSkip = true
-			}
-		}
-		if shouldSkip {
-			continue
-		}
-
-		// Identify items between brackets. Ex: {firstname}
-		if string(dataVal[i]) == "{" {
-			startCurly = i
-			continue
-		}
-		if startCurly != -1 && string(dataVal[i]) == "}" {
-			endCurly = i
-		}
-		if startCurly == -1 || endCurly == -1 {
-			continue
-		}
-
-		// Get the value between brackets
-		fParts := dataVal[startCurly+1 : endCurly]
-
-		// Check if has params separated by :
-		fNameSplit := strings.SplitN(fParts, ":", 2)
-		fName := ""
-		fParams := ""
-		if len(fNameSplit) >= 1 {
-			fName = fNameSplit[0]
-		}
-		if len(fNameSplit) >= 2 {
-			fParams = fNameSplit[1]
-		}
-
-		// Check to see if its a replaceable lookup function
-		if info := GetFuncLookup(fName); info != nil {
-			// Get parameters, make sure params and the split both have values
-			mapParams := NewMapParams()
-			paramsLen := len(info.Params)
-
-			// If just one param and its a string simply just pass it
-			if paramsLen == 1 && info.Params[0].Type == "string" {
-				mapParams.Add(info.Params[0].Field, fParams)
-			} else if paramsLen > 0 && fParams != "" {
-				var err error
-				splitVals, err := funcLookupSplit(fParams)
-				if err != nil {
-					return "", err
-				}
-				mapParams, err = addSplitValsToMapParams(splitVals, info, mapParams)
-				if err != nil {
-					return "", err
-				}
-			}
-			if mapParams.Size() == 0 {
-				mapParams = nil
-			}
-
-			// Call function
-			fValue, err := info.Generate(f, mapParams, info)
-			if err != nil {
-				return "", err
-			}
-
-			// Successfully found, run replace with new value
-			dataVal = strings.Replace(dataVal, "{"+fParts+"}", fmt.Sprintf("%v", fValue), 1)
-
-			// Reset the curly index back to -1 and reset ignores
-			startCurly = -1
-			startCurlyIgnore = []int{}
-			endCurly = -1
-			endCurlyIgnore = []int{}
-			i = -1 // Reset back to the start of the string
-			continue
-		}
-
-		// Couldnt find anything - mark curly brackets to skip and rerun
-		startCurlyIgnore = append(startCurlyIgnore, startCurly)
-		endCurlyIgnore = append(endCurlyIgnore, endCurly)
-
-		// Reset the curly index back to -1
-		startCurly = -1
-		endCurly = -1
-		i = -1 // Reset back to the start of the string
-		continue
-	}
-
-	return dataVal, nil
+	return parsePattern(dataVal).generate(f)
 }
 
-// FixedWidthOptions defines values needed for csv generation
+// FixedWidthOptions defines values needed for fixed width generation
 type FixedWidthOptions struct {
 	RowCount int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
 	Fields   []Field `json:"fields" xml:"fields" fake:"{fields}"`
+
+	// Columns sets the layout of each field, in order. Rows are then written to their exact widths
+	// without a row of field names instead of sizing columns from the data
+	Columns    []FixedWidthColumn `json:"columns" xml:"columns" fake:"skip"`
+	Overflow   string             `json:"overflow" xml:"overflow" fake:"skip"`       // truncate or error when a value is wider than its column, defaults to truncate
+	Header     *FixedWidthRecord  `json:"header" xml:"header" fake:"skip"`           // Record written before the rows
+	Trailer    *FixedWidthRecord  `json:"trailer" xml:"trailer" fake:"skip"`         // Record written after the rows
+	LineEnding string             `json:"line_ending" xml:"line_ending" fake:"skip"` // lf, crlf or none, defaults to lf
+}
+
+// FixedWidthColumn is the layout of a single fixed width field
+type FixedWidthColumn struct {
+	Width    int    `json:"width" xml:"width"`       // Number of characters the value is padded or truncated to
+	Align    string `json:"align" xml:"align"`       // left or right, defaults to left or to right for zero padding
+	Pad      string `json:"pad" xml:"pad"`           // space or zero, defaults to space
+	Decimals int    `json:"decimals" xml:"decimals"` // Implied decimal places. Ex: 12.34 with 2 decimals is written as 1234
+}
+
+// FixedWidthRecord is a header or trailer record laid out with its own fields and columns.
+// A field with the function recordcount outputs the number of rows
+type FixedWidthRecord struct {
+	Fields  []Field            `json:"fields" xml:"fields"`
+	Columns []FixedWidthColumn `json:"columns" xml:"columns"`
 }
 
 // FixedWidth generates an table of random data in fixed width format
@@ -194,6 +139,26 @@ func fixeWidthFunc(f *Faker, co *FixedWidthOptions) (string, error) {
 		}
 	}
 
+	// Check line ending
+	lineEnding := "\n"
+	switch co.LineEnding {
+	case "", "lf":
+	case "crlf":
+		lineEnding = "\r\n"
+	case "none":
+		lineEnding = ""
+	default:
+		return "", errors.New("invalid line ending, must be lf, crlf or none")
+	}
+
+	if co.Overflow != "" && co.Overflow != "truncate" && co.Overflow != "error" {
+		return "", errors.New("invalid overflow, must be truncate or error")
+	}
+
+	if len(co.Columns) > 0 && len(co.Columns) != len(co.Fields) {
+		return "", errors.New("must have a column for each field")
+	}
+
 	data := [][]string{}
 	hasHeader := false
 
@@ -209,7 +174,7 @@ func fixeWidthFunc(f *Faker, co *FixedWidthOptions) (string, error) {
 		row = append(row, field.Name)
 
 		// Get function
-		funcInfo := GetFuncLookup(field.Function)
+		funcInfo := f.GetFuncLookup(field.Function)
 		var value any
 		if funcInfo == nil {
 			// Try to run the function through generate
@@ -225,7 +190,7 @@ func fixeWidthFunc(f *Faker, co *FixedWidthOptions) (string, error) {
 			// Generate function value
 			var err error
 			for i := 0; i < co.RowCount; i++ {
-				value, err = funcInfo.Generate(f, &field.Params, funcInfo)
+				value, err = f.callFuncLookup(field.Function, &field.Params, funcInfo)
 				if err != nil {
 					return "", err
 				}
@@ -239,71 +204,356 @@ func fixeWidthFunc(f *Faker, co *FixedWidthOptions) (string, error) {
 		data = append(data, row)
 	}
 
-	var result strings.Builder
+	records := []string{}
+
+	if co.Header != nil {
+		record, err := fixedWidthRecord(f, co, co.Header)
+		if err != nil {
+			return "", err
+		}
+		records = append(records, record)
+	}
 
-	// Calculate column widths
-	colWidths := make([]int, len(data))
-	for i, row := range data {
-		for _, value := range row {
-			width := len(value) + 5
-			if width > colWidths[i] {
-				colWidths[i] = width
+	if len(co.Columns) > 0 {
+		// Lay out each row to its columns, skipping the field names
+		for i := 1; i < len(data[0]); i++ {
+			values := make([]string, len(data))
+			for j, row := range data {
+				values[j] = row[i]
 			}
+
+			record, err := fixedWidthLayout(values, co.Fields, co.Columns, co.Overflow)
+			if err != nil {
+				return "", err
+			}
+			records = append(records, record)
 		}
+	} else {
+		// Calculate column widths
+		colWidths := make([]int, len(data))
+		for i, row := range data {
+			for _, value := range row {
+				width := len(value) + 5
+				if width > colWidths[i] {
+					colWidths[i] = width
+				}
+			}
+		}
+
+		// Append table rows, excluding the entire row if the first value is empty
+		for i := 0; i < len(data[0]); i++ {
+			if !hasHeader && i == 0 {
+				continue // Skip the entire column if the first value is empty
+			}
+
+			var resultRow strings.Builder
+			for j, row := range data {
+				resultRow.WriteString(fmt.Sprintf("%-*s", colWidths[j], row[i]))
+			}
+
+			// Trim trailing spaces
+			records = append(records, strings.TrimRight(resultRow.String(), " "))
+		}
+	}
+
+	if co.Trailer != nil {
+		record, err := fixedWidthRecord(f, co, co.Trailer)
+		if err != nil {
+			return "", err
+		}
+		records = append(records, record)
+	}
+
+	return strings.Join(records, lineEnding), nil
+}
+
+// fixedWidthRecord will generate a header or trailer record, recordcount fields output the number of rows
+func fixedWidthRecord(f *Faker, co *FixedWidthOptions, record *FixedWidthRecord) (string, error) {
+	if len(record.Columns) != len(record.Fields) {
+		return "", errors.New("header and trailer records must have a column for each field")
 	}
 
-	// Append table rows to the string, excluding the entire row if the first value is empty
-	for i := 0; i < len(data[0]); i++ {
-		if !hasHeader && i == 0 {
-			continue // Skip the entire column if the first value is empty
+	values := make([]string, len(record.Fields))
+	for i, field := range record.Fields {
+		if field.Function == "recordcount" {
+			values[i] = strconv.Itoa(co.RowCount)
+			continue
 		}
 
-		var resultRow strings.Builder
-		for j, row := range data {
-			resultRow.WriteString(fmt.Sprintf("%-*s", colWidths[j], row[i]))
+		value, _, err := f.callField(&field)
+		if err != nil {
+			return "", err
 		}
+		values[i] = anyToString(value)
+	}
 
-		// Trim trailing spaces
-		result.WriteString(strings.TrimRight(resultRow.String(), " "))
+	return fixedWidthLayout(values, record.Fields, record.Columns, co.Overflow)
+}
 
-		// Only add new line if not the last row
-		if i != len(data[0])-1 {
-			result.WriteString("\n")
+// fixedWidthLayout will pad, align and truncate each value to its column and join them into a record
+func fixedWidthLayout(values []string, fields []Field, columns []FixedWidthColumn, overflow string) (string, error) {
+	var sb strings.Builder
+	for i, value := range values {
+		column := columns[i]
+		name := fields[i].Name
+
+		if column.Width <= 0 {
+			return "", fmt.Errorf("column for field %s must have a width", name)
+		}
+		if column.Decimals < 0 {
+			return "", fmt.Errorf("column for field %s cannot have negative decimals", name)
+		}
+
+		pad := " "
+		switch column.Pad {
+		case "", "space":
+		case "zero":
+			pad = "0"
+		default:
+			return "", errors.New("invalid pad, must be space or zero")
+		}
+
+		align := column.Align
+		if align == "" {
+			align = "left"
+			if pad == "0" {
+				align = "right"
+			}
+		}
+		if align != "left" && align != "right" {
+			return "", errors.New("invalid align, must be left or right")
+		}
+
+		// Implied decimals shift the decimal point out of the value
+		if column.Decimals > 0 {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return "", fmt.Errorf("field %s value %s is not a number for implied decimals", name, value)
+			}
+
+			number = math.Round(number * math.Pow10(column.Decimals))
+			if number == 0 {
+				number = 0 // Drop the sign of negative zero
+			}
+			value = strconv.FormatFloat(number, 'f', 0, 64)
+		}
+
+		width := utf8.RuneCountInString(value)
+		if width > column.Width {
+			if overflow == "error" {
+				return "", fmt.Errorf("field %s value %s is wider than %d", name, value, column.Width)
+			}
+
+			value = string([]rune(value)[:column.Width])
+			width = column.Width
+		}
+
+		padding := strings.Repeat(pad, column.Width-width)
+		switch {
+		case align == "left":
+			sb.WriteString(value + padding)
+		case pad == "0" && strings.HasPrefix(value, "-"):
+			// Keep the sign in front of the zeros
+			sb.WriteString("-" + padding + value[1:])
+		default:
+			sb.WriteString(padding + value)
 		}
 	}
 
-	return result.String(), nil
+	return sb.String(), nil
 }
 
+// RegexOptions defines values needed for regex generation
+type RegexOptions struct {
+	MaxRepeat int    `json:"max_repeat" xml:"max_repeat"` // Max times *, + and open ended repeats run. Defaults to 10
+	MinLength int    `json:"min_length" xml:"min_length"` // Min length of the output
+	MaxLength int    `json:"max_length" xml:"max_length"` // Max length of the output, 0 for no max
+	CharSet   string `json:"char_set" xml:"char_set"`     // ascii or unicode. Empty uses ascii for . and negated classes only
+	Match     bool   `json:"match" xml:"match"`           // Check the output matches the regex and retry until it does
+}
+
+// Number of attempts made to satisfy the length range or match check
+const regexAttempts = 100
+
 // Regex will generate a string based upon a RE2 syntax
 func Regex(regexStr string) string { return regex(GlobalFaker, regexStr) }
 
 // Regex will generate a string based upon a RE2 syntax
 func (f *Faker) Regex(regexStr string) string { return regex(f, regexStr) }
 
-func regex(f *Faker, regexStr string) (gen string) {
-	re, err := syntax.Parse(regexStr, syntax.Perl)
+func regex(f *Faker, regexStr string) string {
+	cr, err := CompileRegex(regexStr)
 	if err != nil {
 		return "Could not parse regex string"
 	}
 
+	return cr.Next(f)
+}
+
+// RegexWithOptions will generate a string based upon a RE2 syntax using the given options.
+// A nil RegexOptions uses the defaults
+func RegexWithOptions(regexStr string, ro *RegexOptions) (string, error) {
+	return regexWithOptions(GlobalFaker, regexStr, ro)
+}
+
+// RegexWithOptions will generate a string based upon a RE2 syntax using the given options.
+// A nil RegexOptions uses the defaults
+func (f *Faker) RegexWithOptions(regexStr string, ro *RegexOptions) (string, error) {
+	return regexWithOptions(f, regexStr, ro)
+}
+
+func regexWithOptions(f *Faker, regexStr string, ro *RegexOptions) (string, error) {
+	cr, err := CompileRegexOptions(regexStr, ro)
+	if err != nil {
+		return "", err
+	}
+
+	return cr.Generate(f)
+}
+
+// CompiledRegex is a RE2 syntax regex that has been parsed ahead of time.
+// It is safe for concurrent use and can be reused with any faker
+type CompiledRegex struct {
+	source  string
+	re      *syntax.Regexp
+	options RegexOptions
+
+	// Only set when the options ask for the output to be checked
+	matcher *regexp.Regexp
+}
+
+// CompileRegex will parse a RE2 syntax regex ahead of time.
+// Use it in place of Regex when the same regex is run many times
+func CompileRegex(regexStr string) (*CompiledRegex, error) { return CompileRegexOptions(regexStr, nil) }
+
+// CompileRegexOptions will parse a RE2 syntax regex ahead of time using the given options.
+// A nil RegexOptions uses the defaults
+func CompileRegexOptions(regexStr string, ro *RegexOptions) (*CompiledRegex, error) {
+	re, err := syntax.Parse(regexStr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := &CompiledRegex{source: regexStr, re: re}
+	if ro == nil {
+		return cr, nil
+	}
+
+	if ro.MaxRepeat < 0 {
+		return nil, errors.New("max repeat must be greater than or equal to 0")
+	}
+	if ro.MinLength < 0 || ro.MaxLength < 0 {
+		return nil, errors.New("min and max length must be greater than or equal to 0")
+	}
+	if ro.MaxLength > 0 && ro.MinLength > ro.MaxLength {
+		return nil, errors.New("min length must be less than or equal to max length")
+	}
+	if ro.CharSet != "" && ro.CharSet != "ascii" && ro.CharSet != "unicode" {
+		return nil, errors.New("invalid char set, must be ascii or unicode")
+	}
+	cr.options = *ro
+
+	if ro.Match {
+		cr.matcher, err = regexp.Compile(regexStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cr, nil
+}
+
+// Generate will generate a string matching the regex with the given faker.
+// When a length range is set an error is returned if no output falls within it
+func (cr *CompiledRegex) Generate(f *Faker) (string, error) {
+	matched := false
+	for i := 0; i < regexAttempts; i++ {
+		value, err := cr.generate(f)
+		if err != nil {
+			return "", err
+		}
+
+		// Make sure the output actually matches. Ex: word boundaries or anchors mid pattern
+		if cr.matcher != nil && !cr.matcher.MatchString(value) {
+			continue
+		}
+
+		matched = true
+		if cr.inLength(value) {
+			return value, nil
+		}
+	}
+
+	if !matched {
+		return "", fmt.Errorf("could not generate a value matching regex %s", cr.source)
+	}
+
+	return "", fmt.Errorf("could not generate a value within the length range for regex %s", cr.source)
+}
+
+// Next will generate a string matching the regex with the given faker.
+// Any error results in an empty string, use Generate to get the error
+func (cr *CompiledRegex) Next(f *Faker) string {
+	value, err := cr.Generate(f)
+	if err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// String returns the source text used to compile the regex
+func (cr *CompiledRegex) String() string { return cr.source }
+
+// generate will run a single attempt at generating the regex
+func (cr *CompiledRegex) generate(f *Faker) (gen string, err error) {
 	// Panic catch
 	defer func() {
 		if r := recover(); r != nil {
-			gen = fmt.Sprint(f)
-			return
-
+			err = fmt.Errorf("%v", r)
 		}
 	}()
 
-	return regexGenerate(f, re, len(regexStr)*100)
+	rg := &regexGen{f: f, options: &cr.options}
+	return rg.generate(cr.re, len(cr.source)*10*rg.maxRepeat()), nil
+}
+
+// inLength will return whether the value is within the length range
+func (cr *CompiledRegex) inLength(value string) bool {
+	length := utf8.RuneCountInString(value)
+
+	return length >= cr.options.MinLength && (cr.options.MaxLength == 0 || length <= cr.options.MaxLength)
+}
+
+// regexGen holds the faker and options for a single regex generation
+type regexGen struct {
+	f       *Faker
+	options *RegexOptions
+}
+
+// maxRepeat will return the max times *, + and open ended repeats run.
+// Without a MaxRepeat the default of 10 is raised so the length range can be reached
+func (rg *regexGen) maxRepeat() int {
+	if rg.options.MaxRepeat > 0 {
+		return rg.options.MaxRepeat
+	}
+
+	switch {
+	case rg.options.MaxLength > 0:
+		return max(10, rg.options.MaxLength)
+	case rg.options.MinLength > 0:
+		return max(10, rg.options.MinLength+10)
+	}
+
+	return 10
 }
 
-func regexGenerate(f *Faker, re *syntax.Regexp, limit int) string {
+func (rg *regexGen) generate(re *syntax.Regexp, limit int) string {
 	if limit <= 0 {
 		panic("Length limit reached when generating output")
 	}
 
+	f := rg.f
 	op := re.Op
 	switch op {
 	case syntax.OpNoMatch: // matches no strings
@@ -313,53 +563,18 @@ func regexGenerate(f *Faker, re *syntax.Regexp, limit int) string {
 	case syntax.OpLiteral: // matches Runes sequence
 		var b strings.Builder
 		for _, ru := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 {
+				ru = rg.foldCase(ru)
+			}
 			b.WriteRune(ru)
 		}
 		return b.String()
 	case syntax.OpCharClass: // matches Runes interpreted as range pair list
-		// number of possible chars
-		sum := 0
-		for i := 0; i < len(re.Rune); i += 2 {
-			sum += int(re.Rune[i+1]-re.Rune[i]) + 1
-			if re.Rune[i+1] == 0x10ffff { // rune range end
-				sum = -1
-				break
-			}
-		}
-
-		// pick random char in range (inverse match group)
-		if sum == -1 {
-			chars := []uint8{}
-			for j := 0; j < len(allStr); j++ {
-				c := allStr[j]
-
-				// Check c in range
-				for i := 0; i < len(re.Rune); i += 2 {
-					if rune(c) >= re.Rune[i] && rune(c) <= re.Rune[i+1] {
-						chars = append(chars, c)
-						break
-					}
-				}
-			}
-			if len(chars) > 0 {
-				return string([]byte{chars[f.IntN(len(chars))]})
-			}
-		}
-
-		r := f.IntN(int(sum))
-		var ru rune
-		sum = 0
-		for i := 0; i < len(re.Rune); i += 2 {
-			gap := int(re.Rune[i+1]-re.Rune[i]) + 1
-			if sum+gap > r {
-				ru = re.Rune[i] + rune(r-sum)
-				break
-			}
-			sum += gap
-		}
-
-		return string(ru)
+		return rg.charClass(re.Rune)
 	case syntax.OpAnyCharNotNL, syntax.OpAnyChar: // matches any character(and except newline)
+		if rg.options.CharSet == "unicode" {
+			return string(rg.unicodeChar(op == syntax.OpAnyCharNotNL))
+		}
 		return randCharacter(f, allStr)
 	case syntax.OpBeginLine: // matches empty string at beginning of line
 	case syntax.OpEndLine: // matches empty string at end of line
@@ -368,57 +583,446 @@ func regexGenerate(f *Faker, re *syntax.Regexp, limit int) string {
 	case syntax.OpWordBoundary: // matches word boundary `\b`
 	case syntax.OpNoWordBoundary: // matches word non-boundary `\B`
 	case syntax.OpCapture: // capturing subexpression with index Cap, optional name Name
-		return regexGenerate(f, re.Sub0[0], limit)
+		return rg.generate(re.Sub0[0], limit)
 	case syntax.OpStar: // matches Sub[0] zero or more times
 		var b strings.Builder
-		for i := 0; i < number(f, 0, 10); i++ {
+		count := number(f, 0, rg.maxRepeat())
+		for i := 0; i < count; i++ {
 			for _, rs := range re.Sub {
-				b.WriteString(regexGenerate(f, rs, limit-b.Len()))
+				b.WriteString(rg.generate(rs, limit-b.Len()))
 			}
 		}
 		return b.String()
 	case syntax.OpPlus: // matches Sub[0] one or more times
 		var b strings.Builder
-		for i := 0; i < number(f, 1, 10); i++ {
+		count := number(f, 1, rg.maxRepeat())
+		for i := 0; i < count; i++ {
 			for _, rs := range re.Sub {
-				b.WriteString(regexGenerate(f, rs, limit-b.Len()))
+				b.WriteString(rg.generate(rs, limit-b.Len()))
 			}
 		}
 		return b.String()
 	case syntax.OpQuest: // matches Sub[0] zero or one times
 		var b strings.Builder
-		for i := 0; i < number(f, 0, 1); i++ {
+		count := number(f, 0, 1)
+		for i := 0; i < count; i++ {
 			for _, rs := range re.Sub {
-				b.WriteString(regexGenerate(f, rs, limit-b.Len()))
+				b.WriteString(rg.generate(rs, limit-b.Len()))
 			}
 		}
 		return b.String()
 	case syntax.OpRepeat: // matches Sub[0] at least Min times, at most Max (Max == -1 is no limit)
 		var b strings.Builder
 		count := 0
-		re.Max = int(math.Min(float64(re.Max), float64(10)))
-		if re.Max > re.Min {
-			count = f.IntN(re.Max - re.Min + 1)
+		maxRepeat := re.Max
+		if maxRepeat == -1 {
+			maxRepeat = int(math.Max(float64(re.Min), float64(rg.maxRepeat())))
+		}
+		maxRepeat = int(math.Min(float64(maxRepeat), float64(rg.maxRepeat())))
+		if maxRepeat > re.Min {
+			count = f.IntN(maxRepeat - re.Min + 1)
 		}
 		for i := 0; i < re.Min || i < (re.Min+count); i++ {
 			for _, rs := range re.Sub {
-				b.WriteString(regexGenerate(f, rs, limit-b.Len()))
+				b.WriteString(rg.generate(rs, limit-b.Len()))
 			}
 		}
 		return b.String()
 	case syntax.OpConcat: // matches concatenation of Subs
 		var b strings.Builder
 		for _, rs := range re.Sub {
-			b.WriteString(regexGenerate(f, rs, limit-b.Len()))
+			b.WriteString(rg.generate(rs, limit-b.Len()))
 		}
 		return b.String()
 	case syntax.OpAlternate: // matches alternation of Subs
-		return regexGenerate(f, re.Sub[number(f, 0, len(re.Sub)-1)], limit)
+		return rg.generate(re.Sub[number(f, 0, len(re.Sub)-1)], limit)
 	}
 
 	return ""
 }
 
+// charClass will pick a random rune from a range pair list
+func (rg *regexGen) charClass(ranges []rune) string {
+	f := rg.f
+
+	// Ascii only, pick from the printable ascii runes within the class
+	if rg.options.CharSet == "ascii" {
+		chars := []rune{}
+		for c := rune(' '); c <= '~'; c++ {
+			if runeInRanges(c, ranges) {
+				chars = append(chars, c)
+			}
+		}
+		if len(chars) > 0 {
+			return string(chars[f.IntN(len(chars))])
+		}
+	}
+
+	// number of possible chars
+	sum := 0
+	for i := 0; i < len(ranges); i += 2 {
+		sum += int(ranges[i+1]-ranges[i]) + 1
+		if ranges[i+1] == unicode.MaxRune && rg.options.CharSet == "" { // rune range end
+			sum = -1
+			break
+		}
+	}
+
+	// pick random char in range (inverse match group)
+	if sum == -1 {
+		chars := []uint8{}
+		for j := 0; j < len(allStr); j++ {
+			c := allStr[j]
+
+			// Check c in range
+			if runeInRanges(rune(c), ranges) {
+				chars = append(chars, c)
+			}
+		}
+		if len(chars) > 0 {
+			return string([]byte{chars[f.IntN(len(chars))]})
+		}
+
+		// Nothing printable in ascii, fall back to the full ranges
+		sum = 0
+		for i := 0; i < len(ranges); i += 2 {
+			sum += int(ranges[i+1]-ranges[i]) + 1
+		}
+	}
+	if sum <= 0 {
+		panic("Empty character class")
+	}
+
+	// Pick a random rune within the ranges, avoiding any that cant be printed
+	var ru rune
+	for attempt := 0; attempt < regexAttempts; attempt++ {
+		r := f.IntN(sum)
+		count := 0
+		for i := 0; i < len(ranges); i += 2 {
+			gap := int(ranges[i+1]-ranges[i]) + 1
+			if count+gap > r {
+				ru = ranges[i] + rune(r-count)
+				break
+			}
+			count += gap
+		}
+
+		if rg.options.CharSet != "unicode" || (utf8.ValidRune(ru) && unicode.IsPrint(ru)) {
+			break
+		}
+	}
+
+	return string(ru)
+}
+
+// unicodeChar will pick a random printable rune
+func (rg *regexGen) unicodeChar(notNL bool) rune {
+	for {
+		ru := rune(rg.f.IntN(0x2ffff-' '+1)) + ' '
+		if unicode.IsPrint(ru) && (!notNL || ru != '\n') {
+			return ru
+		}
+	}
+}
+
+// foldCase will pick a random rune that matches the given rune when case folded
+func (rg *regexGen) foldCase(ru rune) rune {
+	folds := rg.folds(ru)
+	if len(folds) == 1 {
+		return ru
+	}
+
+	return folds[rg.f.IntN(len(folds))]
+}
+
+// folds will return the rune along with every rune it matches when case folded.
+// Unless the char set is unicode only ascii runes are used for ascii input. Ex: k but not the kelvin sign
+func (rg *regexGen) folds(ru rune) []rune {
+	folds := []rune{ru}
+	for r := unicode.SimpleFold(ru); r != ru; r = unicode.SimpleFold(r) {
+		if rg.options.CharSet != "unicode" && ru <= unicode.MaxASCII && r > unicode.MaxASCII {
+			continue
+		}
+		folds = append(folds, r)
+	}
+
+	return folds
+}
+
+// runeInRanges will check if the rune is within a range pair list
+func runeInRanges(c rune, ranges []rune) bool {
+	for i := 0; i < len(ranges); i += 2 {
+		if c >= ranges[i] && c <= ranges[i+1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegexInfinite is returned by RegexCount when a regex matches an unbounded
+// number of strings, or more than fit in an int
+const RegexInfinite = -1
+
+// RegexEnumerate will return an iterator over every string the regex can generate.
+// Unbounded repeats like * and + run up to 10 times, the same as Regex.
+// A limit of 0 or less returns every value.
+// Values are yielded once for each way the regex matches them, so ambiguous
+// regexes repeat values. Ex: a?a? yields "a" twice
+//
+// The iterator can be ranged over in go 1.23+ or called directly with a yield func.
+// Ex: seq(func(value string) bool { fmt.Println(value); return true })
+func RegexEnumerate(regexStr string, limit int) (func(yield func(string) bool), error) {
+	cr, err := CompileRegex(regexStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.Enumerate(limit), nil
+}
+
+// RegexCount will return the number of strings the regex matches.
+// RegexInfinite is returned for unbounded regexes. Ex: a+
+// The count is of the ways the regex can match, so it is an upper bound
+// for ambiguous regexes where values repeat. Ex: a?a? counts 4 for 3 values
+func RegexCount(regexStr string) (int, error) {
+	cr, err := CompileRegex(regexStr)
+	if err != nil {
+		return 0, err
+	}
+
+	return cr.Count(), nil
+}
+
+// Enumerate will return an iterator over every string the regex can generate.
+// Unbounded repeats run up to the max repeat option, which defaults to 10.
+// A limit of 0 or less returns every value. Ambiguous regexes repeat values, see RegexEnumerate
+func (cr *CompiledRegex) Enumerate(limit int) func(yield func(string) bool) {
+	return func(yield func(string) bool) {
+		count := 0
+		rg := &regexGen{options: &cr.options}
+		rg.enumerate(cr.re, "", func(value string) bool {
+			if !yield(value) {
+				return false
+			}
+
+			count++
+			return limit <= 0 || count < limit
+		})
+	}
+}
+
+// Count will return the number of strings the regex matches, an upper bound for ambiguous
+// regexes, or RegexInfinite if it is unbounded or does not fit in an int
+func (cr *CompiledRegex) Count() int {
+	rg := &regexGen{options: &cr.options}
+	count := rg.count(cr.re)
+	if count == nil || !count.IsInt64() || count.Int64() > math.MaxInt {
+		return RegexInfinite
+	}
+
+	return int(count.Int64())
+}
+
+// enumerate will call yield with every string the regex matches, appended to prefix.
+// Returns false once yield asks to stop
+func (rg *regexGen) enumerate(re *syntax.Regexp, prefix string, yield func(string) bool) bool {
+	switch re.Op {
+	case syntax.OpNoMatch: // matches no strings
+		return true
+	case syntax.OpLiteral: // matches Runes sequence
+		return rg.enumerateLiteral(re.Rune, re.Flags&syntax.FoldCase != 0, prefix, yield)
+	case syntax.OpCharClass: // matches Runes interpreted as range pair list
+		return rg.charClassRunes(re.Rune, func(ru rune) bool {
+			return yield(prefix + string(ru))
+		})
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar: // matches any character(and except newline)
+		for i := 0; i < len(allStr); i++ {
+			if !yield(prefix + allStr[i:i+1]) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpCapture: // capturing subexpression with index Cap, optional name Name
+		return rg.enumerate(re.Sub[0], prefix, yield)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		minRepeat, maxRepeat := rg.repeatRange(re)
+		for count := minRepeat; count <= maxRepeat; count++ {
+			if !rg.enumerateRepeat(re.Sub[0], count, prefix, yield) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpConcat: // matches concatenation of Subs
+		return rg.enumerateConcat(re.Sub, prefix, yield)
+	case syntax.OpAlternate: // matches alternation of Subs
+		for _, rs := range re.Sub {
+			if !rg.enumerate(rs, prefix, yield) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Empty match, anchors and word boundaries
+	return yield(prefix)
+}
+
+// enumerateConcat will enumerate each sub in turn, building on the prefix of the last
+func (rg *regexGen) enumerateConcat(subs []*syntax.Regexp, prefix string, yield func(string) bool) bool {
+	if len(subs) == 0 {
+		return yield(prefix)
+	}
+
+	return rg.enumerate(subs[0], prefix, func(value string) bool {
+		return rg.enumerateConcat(subs[1:], value, yield)
+	})
+}
+
+// enumerateRepeat will enumerate the sub repeated exactly count times
+func (rg *regexGen) enumerateRepeat(sub *syntax.Regexp, count int, prefix string, yield func(string) bool) bool {
+	if count == 0 {
+		return yield(prefix)
+	}
+
+	return rg.enumerate(sub, prefix, func(value string) bool {
+		return rg.enumerateRepeat(sub, count-1, value, yield)
+	})
+}
+
+// enumerateLiteral will enumerate a literal and, when case folded, every case of it
+func (rg *regexGen) enumerateLiteral(runes []rune, fold bool, prefix string, yield func(string) bool) bool {
+	if !fold {
+		return yield(prefix + string(runes))
+	}
+	if len(runes) == 0 {
+		return yield(prefix)
+	}
+
+	for _, ru := range rg.folds(runes[0]) {
+		if !rg.enumerateLiteral(runes[1:], fold, prefix+string(ru), yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// count will return the number of strings the regex matches, or nil if unbounded
+func (rg *regexGen) count(re *syntax.Regexp) *big.Int {
+	switch re.Op {
+	case syntax.OpNoMatch: // matches no strings
+		return big.NewInt(0)
+	case syntax.OpLiteral: // matches Runes sequence
+		count := big.NewInt(1)
+		if re.Flags&syntax.FoldCase != 0 {
+			for _, ru := range re.Rune {
+				count.Mul(count, big.NewInt(int64(len(rg.folds(ru)))))
+			}
+		}
+		return count
+	case syntax.OpCharClass: // matches Runes interpreted as range pair list
+		count := int64(0)
+		rg.charClassRunes(re.Rune, func(ru rune) bool {
+			count++
+			return true
+		})
+		return big.NewInt(count)
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar: // matches any character(and except newline)
+		return big.NewInt(int64(len(allStr)))
+	case syntax.OpCapture: // capturing subexpression with index Cap, optional name Name
+		return rg.count(re.Sub[0])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		sub := rg.count(re.Sub[0])
+		if sub != nil && sub.Sign() == 0 {
+			// Only the empty repeat can match
+			if re.Op == syntax.OpStar || re.Op == syntax.OpQuest || re.Min == 0 && re.Op == syntax.OpRepeat {
+				return big.NewInt(1)
+			}
+			return big.NewInt(0)
+		}
+		if sub == nil || re.Op == syntax.OpStar || re.Op == syntax.OpPlus || re.Op == syntax.OpRepeat && re.Max == -1 {
+			return nil
+		}
+
+		minRepeat, maxRepeat := 0, 1
+		if re.Op == syntax.OpRepeat {
+			minRepeat, maxRepeat = re.Min, re.Max
+		}
+
+		// Sum of sub^n for each repeat count
+		count := big.NewInt(0)
+		for n := minRepeat; n <= maxRepeat; n++ {
+			count.Add(count, new(big.Int).Exp(sub, big.NewInt(int64(n)), nil))
+		}
+		return count
+	case syntax.OpConcat: // matches concatenation of Subs
+		count := big.NewInt(1)
+		for _, rs := range re.Sub {
+			sub := rg.count(rs)
+			if sub == nil {
+				return nil
+			}
+			count.Mul(count, sub)
+		}
+		return count
+	case syntax.OpAlternate: // matches alternation of Subs
+		count := big.NewInt(0)
+		for _, rs := range re.Sub {
+			sub := rg.count(rs)
+			if sub == nil {
+				return nil
+			}
+			count.Add(count, sub)
+		}
+		return count
+	}
+
+	// Empty match, anchors and word boundaries
+	return big.NewInt(1)
+}
+
+// repeatRange will return the min and max times a repeat runs when enumerated
+func (rg *regexGen) repeatRange(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, rg.maxRepeat()
+	case syntax.OpPlus:
+		return 1, int(math.Max(1, float64(rg.maxRepeat())))
+	case syntax.OpQuest:
+		return 0, 1
+	}
+
+	if re.Max == -1 {
+		return re.Min, int(math.Max(float64(re.Min), float64(rg.maxRepeat())))
+	}
+
+	return re.Min, re.Max
+}
+
+// charClassRunes will call yield with every rune in a range pair list.
+// Like generation, classes that run to the end of unicode only use printable ascii
+func (rg *regexGen) charClassRunes(ranges []rune, yield func(rune) bool) bool {
+	if len(ranges) > 0 && ranges[len(ranges)-1] == unicode.MaxRune && rg.options.CharSet != "unicode" {
+		for i := 0; i < len(allStr); i++ {
+			if runeInRanges(rune(allStr[i]), ranges) && !yield(rune(allStr[i])) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := 0; i < len(ranges); i += 2 {
+		for ru := ranges[i]; ru <= ranges[i+1]; ru++ {
+			if !yield(ru) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // Map will generate a random set of map data
 func Map() map[string]any { return mapFunc(GlobalFaker) }
 
@@ -520,6 +1124,11 @@ Lura Lockman       zacherykuhic@feil.name         S8gV7Z64KlHG     12`,
 		Params: []Param{
 			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "10", Description: "Number of rows"},
 			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields name, function and params"},
+			{Field: "columns", Display: "Columns", Type: "[]FixedWidthColumn", Optional: true, Description: "Width, align, pad and implied decimals of each field, in order"},
+			{Field: "overflow", Display: "Overflow", Type: "string", Default: "truncate", Options: []string{"truncate", "error"}, Description: "Truncate or error when a value is wider than its column"},
+			{Field: "header", Display: "Header", Type: "FixedWidthRecord", Optional: true, Description: "Record before the rows with its own fields and columns"},
+			{Field: "trailer", Display: "Trailer", Type: "FixedWidthRecord", Optional: true, Description: "Record after the rows with its own fields and columns"},
+			{Field: "lineending", Display: "Line Ending", Type: "string", Default: "lf", Options: []string{"lf", "crlf", "none"}, Description: "Line terminator between records"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			co := FixedWidthOptions{}
@@ -547,6 +1156,39 @@ Lura Lockman       zacherykuhic@feil.name         S8gV7Z64KlHG     12`,
 				return nil, errors.New("missing fields")
 			}
 
+			columns, _ := info.GetStringArray(m, "columns")
+			if len(columns) > 0 {
+				co.Columns = make([]FixedWidthColumn, len(columns))
+				for i, c := range columns {
+					err = json.Unmarshal([]byte(c), &co.Columns[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			co.Overflow, _ = info.GetString(m, "overflow")
+			co.LineEnding, _ = info.GetString(m, "lineending")
+
+			// Header and trailer records are json objects
+			header, _ := info.GetString(m, "header")
+			if header != "" {
+				co.Header = &FixedWidthRecord{}
+				err = json.Unmarshal([]byte(header), co.Header)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			trailer, _ := info.GetString(m, "trailer")
+			if trailer != "" {
+				co.Trailer = &FixedWidthRecord{}
+				err = json.Unmarshal([]byte(trailer), co.Trailer)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			out, err := fixeWidthFunc(f, &co)
 			if err != nil {
 				return nil, err
@@ -576,7 +1218,12 @@ Lura Lockman       zacherykuhic@feil.name         S8gV7Z64KlHG     12`,
 				return nil, errors.New("string length is too large. limit to 500 characters")
 			}
 
-			return regex(f, str), nil
+			cr, err := CompileRegex(str)
+			if err != nil {
+				return nil, err
+			}
+
+			return cr.Generate(f)
 		},
 	})
 
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 )
 
 func TestGenerate(t *testing.T) {
@@ -49,6 +52,219 @@ func TestGenerate_Sub(t *testing.T) {
 	})
 }
 
+func TestGenerateEscapes(t *testing.T) {
+	tests := map[string]string{
+		`\#\?`:                     "#?",
+		`\{firstname\}`:            "{firstname}",
+		`{{firstname}}`:            "{firstname}",
+		`{{ .Name }}`:              "{ .Name }",
+		`a\|b \\ \d`:               `a|b \ \d`,
+		`{unknown}`:                "{unknown}",
+		`{unknown:a\|b}`:           "{unknown:a|b}",
+		`{firstname`:               "{firstname",
+		`}firstname}`:              "}firstname}",
+		`{randomstring:[a\{b]}`:    "a{b",
+		`{randomstring:[a\|b]}`:    "a|b",
+		`{randomstring:[x]|upper}`: "X",
+	}
+
+	for pattern, want := range tests {
+		got, err := New(11).Generate(pattern)
+		if err != nil {
+			t.Fatalf("%s: %v", pattern, err)
+		}
+		if got != want {
+			t.Errorf("Generate(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestGenerateEscapesMixed(t *testing.T) {
+	value, err := New(11).Generate(`\##-\??-{{{lettern:2}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !regexp.MustCompile(`^#[0-9]-\?[a-zA-Z]-\{[a-zA-Z]{2}\}$`).MatchString(value) {
+		t.Errorf("unexpected value %q", value)
+	}
+}
+
+func TestGenerateLeadingZero(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		value, err := Generate("0##")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value[0] == '0' {
+			t.Fatalf("expected leading zero to be replaced, got %s", value)
+		}
+	}
+}
+
+func TestGenerateNestedUnknown(t *testing.T) {
+	value, err := New(11).Generate("{regex:[a-c]{5}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[a-c]{5}$`).MatchString(value) {
+		t.Errorf("unexpected value %q", value)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	p := parsePattern(`#?\#{number:1,#}|{{`)
+	if p.digits != 2 || p.letters != 1 {
+		t.Errorf("expected 2 digits and 1 letter, got %d and %d", p.digits, p.letters)
+	}
+	if len(p.nodes) != 5 {
+		t.Fatalf("expected 5 nodes, got %d", len(p.nodes))
+	}
+	if p.nodes[3].kind != patternGroup {
+		t.Errorf("expected group node")
+	}
+	if p.nodes[4].literal != "|{" {
+		t.Errorf("expected literal |{, got %q", p.nodes[4].literal)
+	}
+}
+
+func TestGenerateAlternatives(t *testing.T) {
+	f := New(11)
+
+	tests := []struct {
+		pattern string
+		check   func(string) bool
+	}{
+		{"{a|b|c}", func(s string) bool { return s == "a" || s == "b" || s == "c" }},
+		{"{red|green} car", func(s string) bool { return s == "red car" || s == "green car" }},
+		{"{{firstname}|#}", func(s string) bool { return s != "" }},
+		{"{yes|no|upper}", func(s string) bool { return s == "YES" || s == "NO" }},
+		{"{active:80|suspended:15|deleted:5}", func(s string) bool {
+			return s == "active" || s == "suspended" || s == "deleted"
+		}},
+		{"user[-{number:1,9}]?", func(s string) bool {
+			return s == "user" || regexp.MustCompile(`^user-[1-9]$`).MatchString(s)
+		}},
+		{"a[b]?100%c", func(s string) bool { return s == "abc" }},
+		{"a[b]?0%c", func(s string) bool { return s == "ac" }},
+		{"[x]", func(s string) bool { return s == "[x]" }},
+		{`\[x\]?`, func(s string) bool { return strings.HasPrefix(s, "[x]") && len(s) == 4 }},
+		{"{{firstname}}", func(s string) bool { return s == "{firstname}" }},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			value, err := f.Generate(tt.pattern)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.pattern, err)
+			}
+			if !tt.check(value) {
+				t.Fatalf("%s produced unexpected value %q", tt.pattern, value)
+			}
+		}
+	}
+}
+
+func TestGenerateAlternativesWeighted(t *testing.T) {
+	f := New(11)
+
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		value, err := f.Generate("{active:80|suspended:20|deleted:0}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[value]++
+	}
+
+	if counts["deleted"] != 0 {
+		t.Errorf("expected zero weight to never be picked, got %d", counts["deleted"])
+	}
+	if counts["active"] < 700 || counts["active"] > 900 {
+		t.Errorf("expected active around 800, got %d", counts["active"])
+	}
+	if counts["active"]+counts["suspended"] != 1000 {
+		t.Errorf("unexpected values %v", counts)
+	}
+}
+
+func TestGenerateAlternativesFunctions(t *testing.T) {
+	// Functions with params still take precedence over alternatives
+	value, err := New(11).Generate("{randomstring:[a,b]}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "a" && value != "b" {
+		t.Errorf("unexpected value %q", value)
+	}
+
+	// Each alternative may be a nested pattern
+	for i := 0; i < 20; i++ {
+		value, err = New(uint64(i + 1)).Generate("{{email}:80|{phone}:20}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(value, "@") && !regexp.MustCompile(`^\d{10}$`).MatchString(value) {
+			t.Errorf("expected email or phone, got %q", value)
+		}
+	}
+
+	// Function names are not resolved inside alternatives, so anything
+	// after a function name that is not a transform is an error
+	for _, pattern := range []string{"{firstname|lowr}", "{email|phone}", "{number|lowr}"} {
+		if _, err := New(11).Generate(pattern); err == nil {
+			t.Errorf("%s: expected error for unknown transform", pattern)
+		}
+	}
+	_, err = New(11).Generate("{firstname|lowr}")
+	if err == nil || err.Error() != `unknown transform "lowr"` {
+		t.Errorf("expected unknown transform error, got %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		value, err = New(uint64(i + 1)).Generate("{{email}|{phone}}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(value, "@") && !regexp.MustCompile(`^\d{10}$`).MatchString(value) {
+			t.Errorf("expected email or phone, got %q", value)
+		}
+	}
+}
+
+func TestGenerateAlternativesStruct(t *testing.T) {
+	var s struct {
+		Status string `fake:"{active:80|suspended:15|deleted:5}"`
+		Name   string `fake:"{firstname}[ {middlename}]? {lastname}"`
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := New(uint64(i + 1)).Struct(&s); err != nil {
+			t.Fatal(err)
+		}
+		if s.Status != "active" && s.Status != "suspended" && s.Status != "deleted" {
+			t.Fatalf("unexpected status %q", s.Status)
+		}
+		if n := len(strings.Fields(s.Name)); n < 2 || n > 3 {
+			t.Fatalf("unexpected name %q", s.Name)
+		}
+	}
+}
+
+func TestGenerateAlternativesCompiled(t *testing.T) {
+	cp, err := CompilePattern("{a:1|b:0}[-{number:1,9}]?100%")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := New(11)
+	for i := 0; i < 20; i++ {
+		value := cp.Next(f)
+		if !regexp.MustCompile(`^a-[1-9]$`).MatchString(value) {
+			t.Fatalf("unexpected value %q", value)
+		}
+	}
+}
+
 func ExampleGenerate() {
 	Seed(11)
 
@@ -210,6 +426,43 @@ func ExampleFaker_FixedWidth() {
 	// Julius Farrell       tomasaullrich@hane.name        e8W8SJGZi1Y4     77
 }
 
+func ExampleFixedWidth_columns() {
+	Seed(11)
+
+	value, err := FixedWidth(&FixedWidthOptions{
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "id", Function: "{number:1,999}"},
+			{Name: "name", Function: "{firstname} {lastname}"},
+			{Name: "amount", Function: "price", Params: MapParams{"min": {"1"}, "max": {"500"}}},
+		},
+		Columns: []FixedWidthColumn{
+			{Width: 6, Pad: "zero"},
+			{Width: 15},
+			{Width: 9, Align: "right", Decimals: 2},
+		},
+		Header: &FixedWidthRecord{
+			Fields:  []Field{{Value: "HDR"}, {Function: "{year}"}},
+			Columns: []FixedWidthColumn{{Width: 3}, {Width: 27, Align: "right"}},
+		},
+		Trailer: &FixedWidthRecord{
+			Fields:  []Field{{Value: "TRL"}, {Function: "recordcount"}},
+			Columns: []FixedWidthColumn{{Width: 3}, {Width: 27, Pad: "zero"}},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: HDR                       1993
+	// 000895Cole Leannon       47435
+	// 000856Darien Rempel      32568
+	// 000200Kaitlyn Wilderm    32002
+	// TRL000000000000000000000000003
+}
+
 func TestFixedWidthLookup(t *testing.T) {
 	faker := New(0)
 
@@ -239,6 +492,137 @@ func TestFixedWidthLookup(t *testing.T) {
 
 }
 
+func TestFixedWidthColumns(t *testing.T) {
+	tests := []struct {
+		value  string
+		column FixedWidthColumn
+		want   string
+	}{
+		{"abc", FixedWidthColumn{Width: 5}, "abc  "},
+		{"abc", FixedWidthColumn{Width: 5, Align: "right"}, "  abc"},
+		{"42", FixedWidthColumn{Width: 5, Pad: "zero"}, "00042"},
+		{"42", FixedWidthColumn{Width: 5, Pad: "zero", Align: "left"}, "42000"},
+		{"-42", FixedWidthColumn{Width: 5, Pad: "zero"}, "-0042"},
+		{"12.345", FixedWidthColumn{Width: 7, Pad: "zero", Decimals: 2}, "0001235"},
+		{"-1.5", FixedWidthColumn{Width: 6, Pad: "zero", Decimals: 3}, "-01500"},
+		{"-0.001", FixedWidthColumn{Width: 3, Pad: "zero", Decimals: 2}, "000"},
+		{"7", FixedWidthColumn{Width: 4, Decimals: 1}, "70  "},
+		{"héllo", FixedWidthColumn{Width: 6}, "héllo "},
+		{"truncated", FixedWidthColumn{Width: 5}, "trunc"},
+	}
+
+	for _, test := range tests {
+		got, err := fixedWidthLayout([]string{test.value}, []Field{{Name: "value"}}, []FixedWidthColumn{test.column}, "")
+		if err != nil {
+			t.Errorf("%s %+v: %s", test.value, test.column, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s %+v: expected %q, got %q", test.value, test.column, test.want, got)
+		}
+	}
+}
+
+func TestFixedWidthRecords(t *testing.T) {
+	value, err := New(11).FixedWidth(&FixedWidthOptions{
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "code", Function: "{lettern:3}"},
+			{Name: "qty", Function: "{number:1,99}"},
+		},
+		Columns: []FixedWidthColumn{{Width: 4}, {Width: 3, Pad: "zero"}},
+		Header: &FixedWidthRecord{
+			Fields:  []Field{{Value: "H"}, {Value: "BATCH"}},
+			Columns: []FixedWidthColumn{{Width: 1}, {Width: 6}},
+		},
+		Trailer: &FixedWidthRecord{
+			Fields:  []Field{{Value: "T"}, {Function: "recordcount"}},
+			Columns: []FixedWidthColumn{{Width: 1}, {Width: 6, Pad: "zero"}},
+		},
+		LineEnding: "crlf",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := strings.Split(value, "\r\n")
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records, got %d: %q", len(records), value)
+	}
+	if records[0] != "HBATCH " || records[4] != "T000003" {
+		t.Errorf("unexpected header or trailer %q", value)
+	}
+	for _, record := range records {
+		if len(record) != 7 {
+			t.Errorf("expected every record to be 7 wide, got %q", record)
+		}
+	}
+
+	// Without a line ending every record is back to back
+	value, err = New(11).FixedWidth(&FixedWidthOptions{
+		RowCount:   4,
+		Fields:     []Field{{Name: "code", Function: "{lettern:3}"}},
+		Columns:    []FixedWidthColumn{{Width: 5}},
+		LineEnding: "none",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(value) != 20 || strings.Contains(value, "\n") {
+		t.Errorf("expected 4 records of 5 without line endings, got %q", value)
+	}
+}
+
+func TestFixedWidthErrors(t *testing.T) {
+	fields := []Field{{Name: "name", Function: "firstname"}}
+
+	tests := map[string]*FixedWidthOptions{
+		"missing column":  {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{Width: 5}, {Width: 5}}},
+		"no width":        {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{}}},
+		"negative":        {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{Width: 5, Decimals: -1}}},
+		"invalid pad":     {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{Width: 5, Pad: "dash"}}},
+		"invalid align":   {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{Width: 5, Align: "center"}}},
+		"not a number":    {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{Width: 5, Decimals: 2}}},
+		"overflow":        {RowCount: 1, Fields: fields, Columns: []FixedWidthColumn{{Width: 1}}, Overflow: "error"},
+		"invalid flow":    {RowCount: 1, Fields: fields, Overflow: "wrap"},
+		"invalid ending":  {RowCount: 1, Fields: fields, LineEnding: "cr"},
+		"header columns":  {RowCount: 1, Fields: fields, Header: &FixedWidthRecord{Fields: fields}},
+		"trailer invalid": {RowCount: 1, Fields: fields, Trailer: &FixedWidthRecord{Fields: []Field{{Function: "notafunction"}}, Columns: []FixedWidthColumn{{Width: 5}}}},
+	}
+
+	for name, co := range tests {
+		_, err := New(11).FixedWidth(co)
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFixedWidthLookupColumns(t *testing.T) {
+	info := GetFuncLookup("fixed_width")
+
+	m := MapParams{
+		"rowcount": {"2"},
+		"fields": {
+			`{"name":"code","function":"{lettern:4}"}`,
+			`{"name":"amount","function":"price","params":{"min":["1"],"max":["99"]}}`,
+		},
+		"columns":    {`{"width":6}`, `{"width":8,"pad":"zero","decimals":2}`},
+		"trailer":    {`{"fields":[{"value":"TRL"},{"function":"recordcount"}],"columns":[{"width":3},{"width":11,"pad":"zero"}]}`},
+		"lineending": {"crlf"},
+	}
+
+	output, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := strings.Split(output.(string), "\r\n")
+	if len(records) != 3 || records[2] != "TRL00000000002" || len(records[0]) != 14 {
+		t.Errorf("unexpected fixed width %q", output)
+	}
+}
+
 func TestFixedWidthNoOptions(t *testing.T) {
 	Seed(11)
 
@@ -413,6 +797,331 @@ func BenchmarkRegexEmail(b *testing.B) {
 	}
 }
 
+func ExampleCompileRegex() {
+	re, err := CompileRegex(`^[a-z]{5,10}@[a-z]{5,10}\.(com|net|org)$`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	f := New(11)
+	fmt.Println(re.Next(f))
+
+	// Output: wffngsnyvx@tyyxi.net
+}
+
+func TestCompileRegex(t *testing.T) {
+	for _, r := range regexes {
+		re, err := CompileRegex(r.test)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Should match the output of Regex with the same seed
+		f1 := New(11)
+		f2 := New(11)
+		for i := 0; i < 10; i++ {
+			if got, want := re.Next(f1), f2.Regex(r.test); got != want {
+				t.Fatalf("%s: expected %s, got %s", r.test, want, got)
+			}
+		}
+	}
+
+	if _, err := CompileRegex(`(hello|world`); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func TestCompileRegexConcurrent(t *testing.T) {
+	re, err := CompileRegex(`^[a-z]{2,20}\d{0,50}$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := regexp.MustCompile(re.String())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(seed uint64) {
+			defer wg.Done()
+
+			f := New(seed)
+			for ii := 0; ii < 100; ii++ {
+				if value := re.Next(f); !reg.MatchString(value) {
+					t.Errorf("generated %s does not match regex", value)
+				}
+			}
+		}(uint64(i + 1))
+	}
+	wg.Wait()
+}
+
+func ExampleRegexWithOptions() {
+	Seed(11)
+
+	value, err := RegexWithOptions(`[a-z]+\d*`, &RegexOptions{MaxRepeat: 3, MaxLength: 4})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(value)
+
+	// Output: l7
+}
+
+func TestRegexWithOptions(t *testing.T) {
+	f := New(11)
+
+	tests := []struct {
+		regex   string
+		options *RegexOptions
+		check   func(string) bool
+	}{
+		{`a*`, &RegexOptions{MaxRepeat: 3}, func(s string) bool { return len(s) <= 3 }},
+		{`a{2,}`, &RegexOptions{MaxRepeat: 50}, func(s string) bool { return len(s) >= 2 && len(s) <= 50 }},
+		{`[a-z]{1,40}`, &RegexOptions{MaxRepeat: 50, MinLength: 20, MaxLength: 25}, func(s string) bool { return len(s) >= 20 && len(s) <= 25 }},
+		{`[a-z]+`, &RegexOptions{MinLength: 20, MaxLength: 25}, func(s string) bool { return len(s) >= 20 && len(s) <= 25 }},
+		{`[a-z]*`, &RegexOptions{MinLength: 30}, func(s string) bool { return len(s) >= 30 }},
+		{`\p{Greek}{5}`, nil, func(s string) bool { return regexp.MustCompile(`^\p{Greek}{5}$`).MatchString(s) }},
+		{`[a-zα-ω]{20}`, &RegexOptions{CharSet: "ascii"}, func(s string) bool { return regexp.MustCompile(`^[a-z]{20}$`).MatchString(s) }},
+		{`[^a-z]{20}`, &RegexOptions{CharSet: "unicode"}, func(s string) bool {
+			return utf8.RuneCountInString(s) == 20 && regexp.MustCompile(`^[^a-z]{20}$`).MatchString(s)
+		}},
+		{`.{10}`, &RegexOptions{CharSet: "unicode"}, func(s string) bool { return utf8.RuneCountInString(s) == 10 && utf8.ValidString(s) }},
+		{`(?i)hello`, nil, func(s string) bool { return strings.EqualFold(s, "hello") && s == strings.Map(asciiOnly, s) }},
+		{`\bfoo\b|foo\bbar`, &RegexOptions{Match: true}, func(s string) bool { return s == "foo" }},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 25; i++ {
+			value, err := f.RegexWithOptions(tt.regex, tt.options)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.regex, err)
+			}
+			if !tt.check(value) {
+				t.Fatalf("%s produced unexpected value %q", tt.regex, value)
+			}
+		}
+	}
+}
+
+func TestRegexWithOptionsError(t *testing.T) {
+	if _, err := RegexWithOptions(`(abc`, nil); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+	if _, err := RegexWithOptions(`a`, &RegexOptions{MaxRepeat: -1}); err == nil {
+		t.Error("expected error for negative max repeat")
+	}
+	if _, err := RegexWithOptions(`a`, &RegexOptions{MinLength: 5, MaxLength: 2}); err == nil {
+		t.Error("expected error for min length greater than max length")
+	}
+	if _, err := RegexWithOptions(`[a-z]+`, &RegexOptions{MaxRepeat: 5, MinLength: 20}); err == nil {
+		t.Error("expected error for length range that cannot be reached")
+	}
+	if _, err := RegexWithOptions(`a`, &RegexOptions{CharSet: "ebcdic"}); err == nil {
+		t.Error("expected error for invalid char set")
+	}
+	if _, err := RegexWithOptions(`^(a|b)\b[a-z]{3}$`, &RegexOptions{Match: true}); err == nil {
+		t.Error("expected error for regex that can never match")
+	}
+	if _, err := Generate("{regex:(abc}"); err == nil {
+		t.Error("expected regex lookup to return an error for invalid regex")
+	}
+}
+
+// asciiOnly maps any non ascii rune to the replacement char
+func asciiOnly(r rune) rune {
+	if r > unicode.MaxASCII {
+		return utf8.RuneError
+	}
+	return r
+}
+
+func BenchmarkCompileRegex(b *testing.B) {
+	email := "^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$"
+
+	b.Run("Regex", func(b *testing.B) {
+		f := New(11)
+		for i := 0; i < b.N; i++ {
+			f.Regex(email)
+		}
+	})
+
+	b.Run("Compiled", func(b *testing.B) {
+		f := New(11)
+		re, _ := CompileRegex(email)
+		for i := 0; i < b.N; i++ {
+			re.Next(f)
+		}
+	})
+}
+
+func ExampleRegexEnumerate() {
+	seq, err := RegexEnumerate(`(GET|POST) /v[12]/(users|orders)`, 0)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	seq(func(value string) bool {
+		fmt.Println(value)
+		return true
+	})
+
+	// Output: GET /v1/users
+	// GET /v1/orders
+	// GET /v2/users
+	// GET /v2/orders
+	// POST /v1/users
+	// POST /v1/orders
+	// POST /v2/users
+	// POST /v2/orders
+}
+
+func ExampleRegexCount() {
+	count, _ := RegexCount(`(GET|POST|PUT) /v[12]/(users|orders)`)
+	fmt.Println(count)
+
+	count, _ = RegexCount(`[a-z]+`)
+	fmt.Println(count == RegexInfinite)
+
+	// Output: 12
+	// true
+}
+
+func TestRegexEnumerate(t *testing.T) {
+	patterns := []string{
+		`(GET|POST|PUT) /v[12]/(users|orders)`,
+		`[ab]{1,3}`,
+		`x?y?z?`,
+		`(?i)ab`,
+		`[^a-z]`,
+		`^\d{2}$`,
+		`(a|b)(c|d)?`,
+	}
+
+	for _, pattern := range patterns {
+		seq, err := RegexEnumerate(pattern, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reg := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		values := 0
+		seen := map[string]bool{}
+		seq(func(value string) bool {
+			if !reg.MatchString(value) {
+				t.Errorf("%s: enumerated value %q does not match", pattern, value)
+			}
+			if seen[value] {
+				t.Errorf("%s: duplicate value %q", pattern, value)
+			}
+			seen[value] = true
+			values++
+			return true
+		})
+
+		count, err := RegexCount(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != values {
+			t.Errorf("%s: count %d does not match %d enumerated values", pattern, count, values)
+		}
+	}
+}
+
+func TestRegexEnumerateAmbiguous(t *testing.T) {
+	// Each way of matching is walked, so values repeat and the count is an upper bound
+	seq, err := RegexEnumerate(`a?a?`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []string{}
+	seen := map[string]bool{}
+	seq(func(value string) bool {
+		values = append(values, value)
+		seen[value] = true
+		return true
+	})
+
+	count, err := RegexCount(`a?a?`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 4 || len(seen) != 3 || count != 4 {
+		t.Errorf("expected 4 values with 3 distinct and a count of 4, got %q and %d", values, count)
+	}
+}
+
+func TestRegexEnumerateLimit(t *testing.T) {
+	seq, err := RegexEnumerate(`[a-z]+`, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []string{}
+	seq(func(value string) bool {
+		values = append(values, value)
+		return true
+	})
+	if strings.Join(values, ",") != "a,b,c,d,e" {
+		t.Errorf("expected first 5 values, got %v", values)
+	}
+
+	// Stopping early should end the enumeration
+	values = values[:0]
+	seq(func(value string) bool {
+		values = append(values, value)
+		return len(values) < 2
+	})
+	if len(values) != 2 {
+		t.Errorf("expected 2 values, got %d", len(values))
+	}
+
+	if _, err := RegexEnumerate(`(abc`, 0); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func TestRegexCount(t *testing.T) {
+	tests := map[string]int{
+		`a`:                  1,
+		`[ab]{2}`:            4,
+		`a?`:                 2,
+		`x{0,3}`:             4,
+		`(?i)ab`:             4,
+		`^$`:                 1,
+		`[^\x00-\x{10FFFF}]`: 0,
+		`a*`:                 RegexInfinite,
+		`a{2,}`:              RegexInfinite,
+		`(a|b+)`:             RegexInfinite,
+		`[a-z]{20}`:          RegexInfinite,
+	}
+
+	for pattern, want := range tests {
+		got, err := RegexCount(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("RegexCount(%q) = %d, want %d", pattern, got, want)
+		}
+	}
+
+	if _, err := RegexCount(`(abc`); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func BenchmarkRegexEnumerate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		seq, _ := RegexEnumerate(`(GET|POST|PUT) /v[12]/(users|orders)/\d`, 0)
+		seq(func(value string) bool { return true })
+	}
+}
+
 func ExampleMap() {
 	Seed(11)
 	fmt.Println(Map())
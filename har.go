@@ -0,0 +1,405 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// HAROptions defines values needed for har generation
+type HAROptions struct {
+	EntryCount int       `json:"entry_count" xml:"entry_count" fake:"{number:1,10}"` // Number of requests, defaults to 1
+	Host       string    `json:"host" xml:"host" fake:"skip"`                        // Site the requests are made to, defaults to a random host
+	Start      time.Time `json:"start" xml:"start" fake:"skip"`                      // Time of the first request, defaults to a past date
+	Rate       float64   `json:"rate" xml:"rate" fake:"skip"`                        // Average requests per second, defaults to 10
+	Indent     bool      `json:"indent" xml:"indent"`
+}
+
+// HAR generates a browser session as a har 1.2 http archive, a page with EntryCount requests to one site
+// and their responses, each with its headers, cookies, query string, post data, content and timings.
+// A nil HAROptions returns a random session.
+func HAR(ho *HAROptions) ([]byte, error) { return harFunc(GlobalFaker, ho) }
+
+// HAR generates a browser session as a har 1.2 http archive, a page with EntryCount requests to one site
+// and their responses, each with its headers, cookies, query string, post data, content and timings.
+// A nil HAROptions returns a random session.
+func (f *Faker) HAR(ho *HAROptions) ([]byte, error) { return harFunc(f, ho) }
+
+type harLog struct {
+	Log harLogBody `json:"log"`
+}
+
+type harLogBody struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Pages   []harPage  `json:"pages"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	StartedDateTime string         `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     harPageTimings `json:"pageTimings"`
+}
+
+type harPageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+type harEntry struct {
+	Pageref         string      `json:"pageref"`
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+type harPostData struct {
+	MimeType string         `json:"mimeType"`
+	Params   []harNameValue `json:"params"`
+	Text     string         `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// harTimings are in milliseconds, -1 when they do not apply like connecting on a reused connection
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+func harFunc(f *Faker, ho *HAROptions) ([]byte, error) {
+	if ho == nil {
+		// We didn't get a HAROptions, so create a new random one
+		err := f.Struct(&ho)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entryCount := ho.EntryCount
+	if entryCount <= 0 {
+		entryCount = 1
+	}
+
+	if ho.Rate < 0 {
+		return nil, errors.New("rate must be greater than 0")
+	}
+	rate := ho.Rate
+	if rate == 0 {
+		rate = 10
+	}
+
+	host := ho.Host
+	if host == "" {
+		host = "www." + domainName(f)
+	}
+
+	start := ho.Start
+	if start.IsZero() {
+		start = pastDate(f)
+	}
+
+	har := harLog{Log: harLogBody{
+		Version: "1.2",
+		Creator: harCreator{Name: "gofakeit", Version: "7"},
+		Pages:   []harPage{{StartedDateTime: harTime(start), ID: "page_1", Title: "https://" + host + "/"}},
+		Entries: make([]harEntry, entryCount),
+	}}
+	serverIP := ipv4Address(f)
+
+	now := start
+	var end time.Time
+	for i := range har.Log.Entries {
+		if i > 0 {
+			now = now.Add(logLineGap(f, rate))
+		}
+
+		entry, err := harEntryFunc(f, host, now, i == 0)
+		if err != nil {
+			return nil, err
+		}
+		entry.ServerIPAddress = serverIP
+		har.Log.Entries[i] = *entry
+
+		// The page is loaded when its first request is and all of them are done
+		done := now.Add(time.Duration(entry.Time * float64(time.Millisecond)))
+		if done.After(end) {
+			end = done
+		}
+		if i == 0 {
+			har.Log.Pages[0].PageTimings.OnContentLoad = entry.Time
+		}
+	}
+	har.Log.Pages[0].PageTimings.OnLoad = harMS(float64(end.Sub(start)) / float64(time.Millisecond))
+
+	if ho.Indent {
+		return json.MarshalIndent(har, "", "    ")
+	}
+
+	return json.Marshal(har)
+}
+
+// harEntryFunc will generate a request and response, connecting to the server if it is the first request
+func harEntryFunc(f *Faker, host string, t time.Time, connect bool) (*harEntry, error) {
+	req, err := httpRequest(f, &HTTPRequestOptions{Host: host})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpResponse(f, &HTTPResponseOptions{Request: req}, t)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &harEntry{Pageref: "page_1", StartedDateTime: harTime(t)}
+
+	// Request
+	var reqBody []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		reqBody, _ = io.ReadAll(body)
+	}
+
+	wire, err := httpRequestWire(req)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Request = harRequest{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []harCookie{},
+		Headers:     harHeaders(wire),
+		QueryString: harQuery(req.URL.RawQuery),
+		HeadersSize: bytes.Index(wire, []byte("\r\n\r\n")) + 4,
+		BodySize:    len(reqBody),
+	}
+	for _, cookie := range req.Cookies() {
+		entry.Request.Cookies = append(entry.Request.Cookies, harCookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	if reqBody != nil {
+		mimeType := req.Header.Get("Content-Type")
+		entry.Request.PostData = &harPostData{MimeType: mimeType, Params: []harNameValue{}, Text: string(reqBody)}
+		if mimeType == "application/x-www-form-urlencoded" {
+			entry.Request.PostData.Params = harQuery(string(reqBody))
+		}
+	}
+
+	// Response
+	wire, err = httpResponseWire(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []harCookie{},
+		Headers:     harHeaders(wire),
+		Content:     harContent{Size: len(respBody), MimeType: resp.Header.Get("Content-Type")},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: bytes.Index(wire, []byte("\r\n\r\n")) + 4,
+		BodySize:    len(respBody),
+	}
+	for _, cookie := range resp.Cookies() {
+		entry.Response.Cookies = append(entry.Response.Cookies, harCookie{Name: cookie.Name, Value: cookie.Value, Path: cookie.Path, HTTPOnly: cookie.HttpOnly, Secure: cookie.Secure})
+	}
+	if utf8.Valid(respBody) {
+		entry.Response.Content.Text = string(respBody)
+	} else {
+		entry.Response.Content.Text = base64.StdEncoding.EncodeToString(respBody)
+		entry.Response.Content.Encoding = "base64"
+	}
+
+	// Timings follow the status and kind of route like access log latencies, plus the time to send and receive the bodies
+	kind := httpRouteKind(req.URL.Path)
+	timings := harTimings{Blocked: f.Float64Range(0.5, 5), DNS: -1, Connect: -1, SSL: -1}
+	if connect {
+		timings.DNS = f.Float64Range(5, 80)
+		timings.SSL = f.Float64Range(10, 60)
+		timings.Connect = f.Float64Range(5, 40) + timings.SSL // Connect includes the tls handshake
+	}
+
+	timings.Wait = logLineLatency(f, resp.StatusCode, kind)
+	timings.Send = 0.05 + float64(len(reqBody))/f.Float64Range(2000, 20000)
+	timings.Receive = 0.05 + float64(len(respBody))/f.Float64Range(2000, 20000) // 2 to 20 MB/s
+
+	entry.Timings = harTimings{
+		Blocked: harMS(timings.Blocked),
+		DNS:     harMS(timings.DNS),
+		Connect: harMS(timings.Connect),
+		Send:    harMS(timings.Send),
+		Wait:    harMS(timings.Wait),
+		Receive: harMS(timings.Receive),
+		SSL:     harMS(timings.SSL),
+	}
+
+	// Time is the total of the timings that apply, ssl is already part of connect
+	for _, ms := range []float64{entry.Timings.Blocked, entry.Timings.DNS, entry.Timings.Connect, entry.Timings.Send, entry.Timings.Wait, entry.Timings.Receive} {
+		if ms > 0 {
+			entry.Time += ms
+		}
+	}
+	entry.Time = harMS(entry.Time)
+
+	return entry, nil
+}
+
+// harHeaders will return the headers of a request or response in the order they are written
+func harHeaders(wire []byte) []harNameValue {
+	end := bytes.Index(wire, []byte("\r\n\r\n"))
+	lines := strings.Split(string(wire[:end]), "\r\n")
+
+	headers := []harNameValue{}
+	for _, line := range lines[1:] {
+		name, value, _ := strings.Cut(line, ": ")
+		headers = append(headers, harNameValue{Name: name, Value: value})
+	}
+
+	return headers
+}
+
+// harQuery will return the names and values of a query string in order
+func harQuery(query string) []harNameValue {
+	values := []harNameValue{}
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(pair, "=")
+		name, _ = neturl.QueryUnescape(name)
+		value, _ = neturl.QueryUnescape(value)
+		values = append(values, harNameValue{Name: name, Value: value})
+	}
+
+	return values
+}
+
+// harTime will format a time in iso 8601 with milliseconds
+func harTime(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// harMS will round milliseconds to microseconds, leaving -1 for timings that do not apply
+func harMS(ms float64) float64 {
+	return math.Round(ms*1000) / 1000
+}
+
+func addHARLookup() {
+	AddFuncLookup("har", Info{
+		Display:     "HAR",
+		Category:    "internet",
+		Description: "Http archive of a browser session, a page with requests to a site and their responses in the har 1.2 json format",
+		Example:     `{"log":{"version":"1.2","creator":{"name":"gofakeit","version":"7"},"pages":[{"startedDateTime":"2024-03-01T12:00:00.000Z","id":"page_1","title":"https://www.productinfrastructures.biz/",...}],"entries":[...]}}`,
+		Output:      "[]byte",
+		ContentType: "application/json",
+		Params: []Param{
+			{Field: "entrycount", Display: "Entry Count", Type: "int", Default: "5", Description: "Number of requests"},
+			{Field: "host", Display: "Host", Type: "string", Optional: true, Description: "Site the requests are made to"},
+			{Field: "rate", Display: "Rate", Type: "float", Default: "10", Description: "Average requests per second"},
+			{Field: "indent", Display: "Indent", Type: "bool", Default: "false", Description: "Whether or not to add indents and newlines"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			ho := HAROptions{}
+
+			entryCount, err := info.GetInt(m, "entrycount")
+			if err != nil {
+				return nil, err
+			}
+			ho.EntryCount = entryCount
+
+			ho.Host, _ = info.GetString(m, "host")
+
+			rate, err := info.GetFloat64(m, "rate")
+			if err != nil {
+				return nil, err
+			}
+			ho.Rate = rate
+
+			indent, err := info.GetBool(m, "indent")
+			if err != nil {
+				return nil, err
+			}
+			ho.Indent = indent
+
+			return harFunc(f, &ho)
+		},
+	})
+}
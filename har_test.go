@@ -0,0 +1,188 @@
+package gofakeit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleHAR() {
+	Seed(11)
+
+	value, err := HAR(&HAROptions{
+		EntryCount: 3,
+		Host:       "shop.example.com",
+		Start:      time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	var har harLog
+	json.Unmarshal(value, &har)
+	for _, entry := range har.Log.Entries {
+		fmt.Println(entry.StartedDateTime, entry.Request.Method, entry.Request.URL, entry.Response.Status)
+	}
+
+	// Output: 2024-03-01T12:00:00.000Z GET https://shop.example.com/products/72658/reviews?utm_medium=social&utm_source=facebook 200
+	// 2024-03-01T12:00:00.166Z GET https://shop.example.com/category/troop 304
+	// 2024-03-01T12:00:00.225Z GET https://shop.example.com/login 200
+}
+
+func ExampleFaker_HAR() {
+	f := New(11)
+
+	value, err := f.HAR(&HAROptions{
+		EntryCount: 3,
+		Host:       "shop.example.com",
+		Start:      time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	var har harLog
+	json.Unmarshal(value, &har)
+	for _, entry := range har.Log.Entries {
+		fmt.Println(entry.StartedDateTime, entry.Request.Method, entry.Request.URL, entry.Response.Status)
+	}
+
+	// Output: 2024-03-01T12:00:00.000Z GET https://shop.example.com/products/72658/reviews?utm_medium=social&utm_source=facebook 200
+	// 2024-03-01T12:00:00.166Z GET https://shop.example.com/category/troop 304
+	// 2024-03-01T12:00:00.225Z GET https://shop.example.com/login 200
+}
+
+func TestHAR(t *testing.T) {
+	value, err := New(11).HAR(&HAROptions{EntryCount: 100, Host: "shop.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every required field of har 1.2 is present, arrays are empty rather than null
+	var raw map[string]map[string]any
+	err = json.Unmarshal(value, &raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"version", "creator", "pages", "entries"} {
+		if raw["log"][key] == nil {
+			t.Errorf("expected log.%s", key)
+		}
+	}
+	entry := raw["log"]["entries"].([]any)[0].(map[string]any)
+	for _, key := range []string{"startedDateTime", "time", "request", "response", "cache", "timings"} {
+		if entry[key] == nil {
+			t.Errorf("expected entry %s", key)
+		}
+	}
+	for _, key := range []string{"method", "url", "httpVersion", "cookies", "headers", "queryString", "headersSize", "bodySize"} {
+		if entry["request"].(map[string]any)[key] == nil {
+			t.Errorf("expected request %s", key)
+		}
+	}
+	for _, key := range []string{"status", "statusText", "httpVersion", "cookies", "headers", "content", "redirectURL", "headersSize", "bodySize"} {
+		if entry["response"].(map[string]any)[key] == nil {
+			t.Errorf("expected response %s", key)
+		}
+	}
+
+	var har harLog
+	err = json.Unmarshal(value, &har)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 100 {
+		t.Fatalf("unexpected har version %s with %d entries", har.Log.Version, len(har.Log.Entries))
+	}
+
+	var last time.Time
+	for i, entry := range har.Log.Entries {
+		started, err := time.Parse(time.RFC3339, entry.StartedDateTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if started.Before(last) {
+			t.Errorf("expected %s to not be before %s", started, last)
+		}
+		last = started
+
+		if !strings.HasPrefix(entry.Request.URL, "https://shop.example.com/") || entry.Request.Headers[0].Name != "Host" {
+			t.Errorf("unexpected request %s %v", entry.Request.URL, entry.Request.Headers)
+		}
+		if entry.Response.Content.Size != entry.Response.BodySize && entry.Request.Method != "HEAD" {
+			t.Errorf("content size %d does not match body size %d", entry.Response.Content.Size, entry.Response.BodySize)
+		}
+		if entry.Request.PostData != nil && len(entry.Request.PostData.Text) != entry.Request.BodySize {
+			t.Errorf("post data does not match body size %d", entry.Request.BodySize)
+		}
+
+		// Only the first request connects, the time is the total of the timings
+		timings := entry.Timings
+		if (i == 0) != (timings.DNS > 0 && timings.Connect > timings.SSL && timings.SSL > 0) {
+			t.Errorf("unexpected connection timings %+v for entry %d", timings, i)
+		}
+		total := timings.Blocked + math.Max(timings.DNS, 0) + math.Max(timings.Connect, 0) + timings.Send + timings.Wait + timings.Receive
+		if math.Abs(total-entry.Time) > 0.01 {
+			t.Errorf("expected time %f to be the total of the timings %f", entry.Time, total)
+		}
+	}
+}
+
+func TestHARErrors(t *testing.T) {
+	if _, err := New(11).HAR(&HAROptions{Rate: -1}); err == nil {
+		t.Error("expected error for negative rate")
+	}
+}
+
+func TestHAROptionsUnchanged(t *testing.T) {
+	ho := &HAROptions{}
+	if _, err := New(11).HAR(ho); err != nil {
+		t.Fatal(err)
+	}
+	if ho.EntryCount != 0 || ho.Rate != 0 {
+		t.Errorf("expected options to be left as is, got %+v", ho)
+	}
+}
+
+func TestHARNil(t *testing.T) {
+	value, err := New(11).HAR(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(value) {
+		t.Errorf("expected valid json %s", value)
+	}
+}
+
+func TestHARLookup(t *testing.T) {
+	info := GetFuncLookup("har")
+
+	m := MapParams{
+		"entrycount": {"4"},
+		"host":       {"shop.example.com"},
+		"indent":     {"true"},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var har harLog
+	err = json.Unmarshal(value.([]byte), &har)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(har.Log.Entries) != 4 || har.Log.Pages[0].Title != "https://shop.example.com/" || !strings.Contains(string(value.([]byte)), "\n    ") {
+		t.Errorf("unexpected har %s", value)
+	}
+}
+
+func BenchmarkHAR(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		HAR(&HAROptions{EntryCount: 10})
+	}
+}
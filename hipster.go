@@ -63,7 +63,7 @@ func addHipsterLookup() {
 		Example:     "Microdosing roof chia echo pickled.",
 		Output:      "string",
 		Params: []Param{
-			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Description: "Number of words in a sentence"},
+			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Min: "1", Max: "50", Description: "Number of words in a sentence"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			wordCount, err := info.GetInt(m, "wordcount")
@@ -89,9 +89,9 @@ Pug bushwick hella tote bag cliche direct trade waistcoat yr waistcoat knausgaar
 Shabby chic typewriter VHS readymade lo-fi bitters PBR&B gentrify lomo raw denim freegan put a bird on it. Raw denim cliche dreamcatcher pug fixie park trust fund migas fingerstache sriracha +1 mustache. Tilde shoreditch kickstarter franzen dreamcatcher green juice mustache neutra polaroid stumptown organic schlitz. Flexitarian ramps chicharrones kogi lo-fi mustache tilde forage street church-key williamsburg taxidermy. Chia mustache plaid mumblecore squid slow-carb disrupt Thundercats goth shoreditch master direct trade.`,
 		Output: "string",
 		Params: []Param{
-			{Field: "paragraphcount", Display: "Paragraph Count", Type: "int", Default: "2", Description: "Number of paragraphs"},
-			{Field: "sentencecount", Display: "Sentence Count", Type: "int", Default: "2", Description: "Number of sentences in a paragraph"},
-			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Description: "Number of words in a sentence"},
+			{Field: "paragraphcount", Display: "Paragraph Count", Type: "int", Default: "2", Min: "1", Max: "20", Description: "Number of paragraphs"},
+			{Field: "sentencecount", Display: "Sentence Count", Type: "int", Default: "2", Min: "1", Max: "20", Description: "Number of sentences in a paragraph"},
+			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Min: "1", Max: "50", Description: "Number of words in a sentence"},
 			{Field: "paragraphseparator", Display: "Paragraph Separator", Type: "string", Default: "<br />", Description: "String value to add between paragraphs"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
@@ -115,8 +115,8 @@ func addHtmlLookup() {
 		Output:      "string",
 		ContentType: "image/svg+xml",
 		Params: []Param{
-			{Field: "width", Display: "Width", Type: "int", Default: "500", Description: "Width in px"},
-			{Field: "height", Display: "Height", Type: "int", Default: "500", Description: "Height in px"},
+			{Field: "width", Display: "Width", Type: "int", Default: "500", Min: "10", Max: "999", Description: "Width in px"},
+			{Field: "height", Display: "Height", Type: "int", Default: "500", Min: "10", Max: "999", Description: "Height in px"},
 			{Field: "type", Display: "Type", Type: "string", Optional: true, Options: data.GetSubData("html", "svg"), Description: "Sub child element type"},
 			{Field: "colors", Display: "Colors", Type: "[]string", Optional: true, Description: "Hex or RGB array of colors to use"},
 		},
@@ -0,0 +1,205 @@
+// Package httpfake provides an http.RoundTripper that answers requests with responses generated
+// by gofakeit, so http clients can be tested without a network. Requests are matched against
+// rules that set the json body, status, latency and errors of their responses, and a seeded
+// Faker makes the same requests get the same responses on every run.
+//
+//	client := &http.Client{Transport: httpfake.New(11,
+//		httpfake.Rule{Method: "GET", Path: "/users/*", Fields: []gofakeit.Field{
+//			{Name: "id", Function: "uuid"},
+//			{Name: "name", Function: "name"},
+//		}},
+//		httpfake.Rule{Path: "/orders", Status: map[int]float32{200: 9, 503: 1}, ErrorRate: 0.1},
+//	)}
+package httpfake
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+var (
+	// ErrConnectionReset is returned like a server closing the connection before answering
+	ErrConnectionReset error = &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+
+	// ErrTimeout is returned like a server that stopped answering, its Timeout method returns true
+	ErrTimeout error = &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+)
+
+// Rule matches requests by method and path and sets how they are answered.
+// Anything not set is generated to fit the request like gofakeit.HTTPResponse
+type Rule struct {
+	Method     string           // Method to match, empty matches any method
+	Path       string           // Path to match as in path.Match, so * matches within one segment like /users/*
+	Fields     []gofakeit.Field // Fields of the json body
+	Type       string           // object or array json body, defaults to an array for listings
+	RowCount   int              // Number of rows in an array, defaults to 1 to 10
+	Status     map[int]float32  // Weights of the statuses to answer with
+	MinLatency time.Duration    // Least time to wait before answering
+	MaxLatency time.Duration    // Most time to wait before answering, defaults to MinLatency
+	ErrorRate  float64          // Chance from 0 to 1 of failing with an error instead of answering
+	Errors     []error          // Errors to fail with, defaults to ErrConnectionReset and ErrTimeout
+}
+
+// Transport is an http.RoundTripper answering requests with the first rule they match.
+// Requests no rule matches are answered with a 404 Not Found. Transport is safe for concurrent use,
+// requests made one after another with the same seed always get the same responses,
+// apart from the Date header which is the time the request was answered
+type Transport struct {
+	Faker *gofakeit.Faker // Faker generating the responses
+	Rules []Rule          // Rules checked in order
+
+	mu sync.Mutex
+}
+
+// New creates a Transport with a Faker seeded with seed and the rules to answer requests with
+func New(seed uint64, rules ...Rule) *Transport {
+	return &Transport{Faker: gofakeit.New(seed), Rules: rules}
+}
+
+// Client returns an http.Client using the Transport
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip answers a request with a generated response or an injected error,
+// after waiting for the latency of its rule or until the request is cancelled
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	rule, ok := t.match(req)
+	if !ok {
+		rule = &Rule{Status: map[int]float32{http.StatusNotFound: 1}}
+	}
+
+	// Pick everything about the answer at once so concurrent requests don't interleave the faker
+	resp, latency, err := t.answer(req, rule)
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// match will return the first rule matching the method and path of a request
+func (t *Transport) match(req *http.Request) (*Rule, bool) {
+	for i := range t.Rules {
+		rule := &t.Rules[i]
+		if rule.Method != "" && !strings.EqualFold(rule.Method, req.Method) {
+			continue
+		}
+
+		if rule.Path != "" {
+			matched, err := path.Match(rule.Path, req.URL.Path)
+			if err != nil || !matched {
+				continue
+			}
+		}
+
+		return rule, true
+	}
+
+	return nil, false
+}
+
+// answer will pick the latency and either an error or response for a request
+func (t *Transport) answer(req *http.Request, rule *Rule) (*http.Response, time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f := t.Faker
+	if f == nil {
+		f = gofakeit.GlobalFaker
+	}
+
+	latency := rule.MinLatency
+	if rule.MaxLatency > rule.MinLatency {
+		latency += time.Duration(f.Float64() * float64(rule.MaxLatency-rule.MinLatency))
+	}
+
+	if rule.ErrorRate > 0 && f.Float64() < rule.ErrorRate {
+		errs := rule.Errors
+		if len(errs) == 0 {
+			errs = []error{ErrConnectionReset, ErrTimeout}
+		}
+
+		return nil, latency, errs[f.IntN(len(errs))]
+	}
+
+	var status int
+	if len(rule.Status) > 0 {
+		var err error
+		status, err = pickStatus(f, rule.Status)
+		if err != nil {
+			return nil, latency, err
+		}
+	}
+
+	resp, err := f.HTTPResponse(&gofakeit.HTTPResponseOptions{
+		Request:    req,
+		StatusCode: status,
+		Fields:     rule.Fields,
+		Type:       rule.Type,
+		RowCount:   rule.RowCount,
+		Date:       time.Now(),
+	})
+	if err != nil {
+		return nil, latency, err
+	}
+
+	return resp, latency, nil
+}
+
+// pickStatus will pick a weighted status, in order of status so the pick is the same for a seed
+func pickStatus(f *gofakeit.Faker, weights map[int]float32) (int, error) {
+	statuses := make([]int, 0, len(weights))
+	for status := range weights {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+
+	options := make([]any, len(statuses))
+	values := make([]float32, len(statuses))
+	var total float32
+	for i, status := range statuses {
+		if weights[status] < 0 {
+			return 0, errors.New("status weights can not be negative")
+		}
+		options[i] = status
+		values[i] = weights[status]
+		total += weights[status]
+	}
+	if total <= 0 {
+		return 0, errors.New("status weights must add up to more than 0")
+	}
+
+	status, err := f.Weighted(options, values)
+	if err != nil {
+		return 0, err
+	}
+
+	return status.(int), nil
+}
@@ -0,0 +1,222 @@
+package httpfake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+func ExampleNew() {
+	client := New(11,
+		Rule{Method: "GET", Path: "/users/*", Type: "object", Fields: []gofakeit.Field{
+			{Name: "id", Function: "number", Params: gofakeit.MapParams{"min": {"1"}, "max": {"100"}}},
+			{Name: "name", Function: "{firstname} {lastname}"},
+		}},
+	).Client()
+
+	resp, err := client.Get("https://api.example.com/users/42")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Println(resp.Status)
+	fmt.Println(string(body))
+
+	// Output: 200 OK
+	// {"id":21,"name":"Julius Farrell"}
+}
+
+func TestRoundTripRules(t *testing.T) {
+	client := New(11,
+		Rule{Method: "POST", Path: "/users", Status: map[int]float32{201: 1}},
+		Rule{Path: "/users/*", Status: map[int]float32{200: 1}, Type: "object"},
+		Rule{Path: "/users/*", Status: map[int]float32{500: 1}},
+	).Client()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{"POST", "/users", 201},
+		{"GET", "/users", 404}, // Method does not match
+		{"GET", "/users/42", 200},
+		{"DELETE", "/users/42", 200}, // First matching rule wins
+		{"GET", "/users/42/orders", 404},
+		{"GET", "/orders", 404},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, "https://api.example.com"+test.path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != test.status {
+			t.Errorf("%s %s: expected %d, got %d", test.method, test.path, test.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestRoundTripBody(t *testing.T) {
+	client := New(11, Rule{
+		Path:     "/v1/orders",
+		Type:     "array",
+		RowCount: 3,
+		Fields: []gofakeit.Field{
+			{Name: "id", Function: "uuid"},
+			{Name: "total", Function: "price", Params: gofakeit.MapParams{"min": {"1"}, "max": {"100"}}},
+		},
+		Status: map[int]float32{200: 1},
+	}).Client()
+
+	resp, err := client.Get("https://api.example.com/v1/orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var orders []struct {
+		ID    string  `json:"id"`
+		Total float64 `json:"total"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 3 || orders[0].ID == "" || orders[0].Total == 0 {
+		t.Errorf("unexpected orders %+v", orders)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected json content type, got %s", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestRoundTripDeterministic(t *testing.T) {
+	responses := func() string {
+		client := New(11, Rule{Path: "/products/*", Status: map[int]float32{200: 8, 404: 1, 503: 1}, ErrorRate: 0.1}).Client()
+
+		var sb strings.Builder
+		for i := 0; i < 20; i++ {
+			resp, err := client.Get(fmt.Sprintf("https://shop.example.com/products/%d", i))
+			if err != nil {
+				sb.WriteString(err.Error() + "\n")
+				continue
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			sb.WriteString(resp.Status + " " + string(body) + "\n")
+		}
+
+		return sb.String()
+	}
+
+	if first, second := responses(), responses(); first != second {
+		t.Errorf("expected the same responses for the same seed\n%s\n%s", first, second)
+	}
+}
+
+func TestRoundTripStatus(t *testing.T) {
+	client := New(11, Rule{Status: map[int]float32{200: 1, 503: 1}}).Client()
+
+	counts := map[int]int{}
+	for i := 0; i < 200; i++ {
+		resp, err := client.Get("https://api.example.com/api/v1/health")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		counts[resp.StatusCode]++
+	}
+
+	if len(counts) != 2 || counts[200] == 0 || counts[503] == 0 {
+		t.Errorf("expected only 200 and 503 statuses, got %v", counts)
+	}
+}
+
+func TestRoundTripErrors(t *testing.T) {
+	client := New(11, Rule{ErrorRate: 1}).Client()
+
+	var resets, timeouts int
+	for i := 0; i < 50; i++ {
+		_, err := client.Get("https://api.example.com/")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		var netErr net.Error
+		switch {
+		case errors.Is(err, syscall.ECONNRESET):
+			resets++
+		case errors.As(err, &netErr) && netErr.Timeout():
+			timeouts++
+		default:
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	if resets == 0 || timeouts == 0 {
+		t.Errorf("expected resets and timeouts, got %d and %d", resets, timeouts)
+	}
+
+	// Errors can be set
+	refused := errors.New("connection refused")
+	_, err := New(11, Rule{ErrorRate: 1, Errors: []error{refused}}).Client().Get("https://api.example.com/")
+	if !errors.Is(err, refused) {
+		t.Errorf("expected connection refused, got %v", err)
+	}
+
+	// Statuses must have weights
+	_, err = New(11, Rule{Status: map[int]float32{200: 0}}).Client().Get("https://api.example.com/")
+	if err == nil {
+		t.Error("expected error for zero status weights")
+	}
+}
+
+func TestRoundTripLatency(t *testing.T) {
+	transport := New(11, Rule{MinLatency: 20 * time.Millisecond, MaxLatency: 30 * time.Millisecond})
+
+	start := time.Now()
+	resp, err := transport.Client().Get("https://api.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected at least 20ms of latency, got %s", elapsed)
+	}
+
+	// Cancelled requests stop waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.example.com/", nil)
+	_, err = transport.Client().Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func BenchmarkRoundTrip(b *testing.B) {
+	client := New(11, Rule{Path: "/users/*", Fields: []gofakeit.Field{{Name: "name", Function: "name"}}}).Client()
+
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Get("https://api.example.com/users/42")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+}
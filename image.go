@@ -64,8 +64,8 @@ func addImageLookup() {
 		Output:      "[]byte",
 		ContentType: "image/jpeg",
 		Params: []Param{
-			{Field: "width", Display: "Width", Type: "int", Default: "500", Description: "Image width in px"},
-			{Field: "height", Display: "Height", Type: "int", Default: "500", Description: "Image height in px"},
+			{Field: "width", Display: "Width", Type: "int", Default: "500", Min: "10", Max: "999", Description: "Image width in px"},
+			{Field: "height", Display: "Height", Type: "int", Default: "500", Min: "10", Max: "999", Description: "Image height in px"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			width, err := info.GetInt(m, "width")
@@ -96,8 +96,8 @@ func addImageLookup() {
 		Output:      "[]byte",
 		ContentType: "image/png",
 		Params: []Param{
-			{Field: "width", Display: "Width", Type: "int", Default: "500", Description: "Image width in px"},
-			{Field: "height", Display: "Height", Type: "int", Default: "500", Description: "Image height in px"},
+			{Field: "width", Display: "Width", Type: "int", Default: "500", Min: "10", Max: "999", Description: "Image width in px"},
+			{Field: "height", Display: "Height", Type: "int", Default: "500", Min: "10", Max: "999", Description: "Image height in px"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			width, err := info.GetInt(m, "width")
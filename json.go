@@ -1,10 +1,13 @@
 package gofakeit
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 )
@@ -84,40 +87,10 @@ func jsonFunc(f *Faker, jo *JSONOptions) ([]byte, error) {
 	}
 
 	if jo.Type == "object" {
-		v := make(jsonOrderedKeyVal, len(jo.Fields))
-
-		// Loop through fields and add to them to map[string]any
-		for i, field := range jo.Fields {
-			if field.Function == "autoincrement" {
-				// Object only has one
-				v[i] = &jsonKeyVal{Key: field.Name, Value: 1}
-				continue
-			}
-
-			// Get function info
-			funcInfo := GetFuncLookup(field.Function)
-			if funcInfo == nil {
-				return nil, errors.New("invalid function, " + field.Function + " does not exist")
-			}
-
-			// Call function value
-			value, err := funcInfo.Generate(f, &field.Params, funcInfo)
-			if err != nil {
-				return nil, err
-			}
-
-			if _, ok := value.([]byte); ok {
-				// If it's a slice, unmarshal it into an interface
-				var val any
-				err := json.Unmarshal(value.([]byte), &val)
-				if err != nil {
-					return nil, err
-				}
-				value = val
-			}
-
-			v[i] = &jsonKeyVal{Key: field.Name, Value: value}
-
+		// Object only has one row for autoincrement
+		v, err := f.fieldObject(jo.Fields, 1, 0, jsonLeafValue)
+		if err != nil {
+			return nil, err
 		}
 
 		// Marshal into bytes
@@ -139,38 +112,9 @@ func jsonFunc(f *Faker, jo *JSONOptions) ([]byte, error) {
 		v := make([]jsonOrderedKeyVal, jo.RowCount)
 
 		for i := 0; i < int(jo.RowCount); i++ {
-			vr := make(jsonOrderedKeyVal, len(jo.Fields))
-
-			// Loop through fields and add to them to map[string]any
-			for ii, field := range jo.Fields {
-				if field.Function == "autoincrement" {
-					vr[ii] = &jsonKeyVal{Key: field.Name, Value: i + 1} // +1 because index starts with 0
-					continue
-				}
-
-				// Get function info
-				funcInfo := GetFuncLookup(field.Function)
-				if funcInfo == nil {
-					return nil, errors.New("invalid function, " + field.Function + " does not exist")
-				}
-
-				// Call function value
-				value, err := funcInfo.Generate(f, &field.Params, funcInfo)
-				if err != nil {
-					return nil, err
-				}
-
-				if _, ok := value.([]byte); ok {
-					// If it's a slice, unmarshal it into an interface
-					var val any
-					err := json.Unmarshal(value.([]byte), &val)
-					if err != nil {
-						return nil, err
-					}
-					value = val
-				}
-
-				vr[ii] = &jsonKeyVal{Key: field.Name, Value: value}
+			vr, err := f.fieldObject(jo.Fields, i+1, 0, jsonLeafValue) // +1 because index starts with 0
+			if err != nil {
+				return nil, err
 			}
 
 			v[i] = vr
@@ -189,6 +133,85 @@ func jsonFunc(f *Faker, jo *JSONOptions) ([]byte, error) {
 	return nil, errors.New("invalid type, must be array or object")
 }
 
+// WriteNDJSON streams newline delimited json to w, one object per row, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each row.
+// Type and Indent are ignored as each row is written as a single line object.
+func WriteNDJSON(ctx context.Context, w io.Writer, jo *JSONOptions, progress WriteProgress) error {
+	return writeNDJSON(ctx, GlobalFaker, w, jo, progress)
+}
+
+// WriteNDJSON streams newline delimited json to w, one object per row, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each row.
+// Type and Indent are ignored as each row is written as a single line object.
+func (f *Faker) WriteNDJSON(ctx context.Context, w io.Writer, jo *JSONOptions, progress WriteProgress) error {
+	return writeNDJSON(ctx, f, w, jo, progress)
+}
+
+func writeNDJSON(ctx context.Context, f *Faker, w io.Writer, jo *JSONOptions, progress WriteProgress) error {
+	if jo == nil {
+		// We didn't get a JSONOptions, so create a new random one
+		err := f.Struct(&jo)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jo.Fields == nil || len(jo.Fields) <= 0 {
+		return errors.New("must pass fields in order to build json object(s)")
+	}
+
+	// Make sure you set a row count
+	if jo.RowCount <= 0 {
+		return errors.New("must have row count")
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := 1; i <= jo.RowCount; i++ {
+		if err := ctx.Err(); err != nil {
+			bw.Flush()
+			return err
+		}
+
+		v, err := f.fieldObject(jo.Fields, i, 0, jsonLeafValue)
+		if err != nil {
+			return err
+		}
+
+		j, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(j); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(i, jo.RowCount)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// jsonLeafValue will unmarshal byte slice values, like nested json, so they are not base64 encoded
+func jsonLeafValue(value any) (any, error) {
+	b, ok := value.([]byte)
+	if !ok {
+		return value, nil
+	}
+
+	var val any
+	err := json.Unmarshal(b, &val)
+	if err != nil {
+		return nil, err
+	}
+
+	return val, nil
+}
+
 func addFileJSONLookup() {
 	AddFuncLookup("json", Info{
 		Display:     "JSON",
@@ -203,7 +226,7 @@ func addFileJSONLookup() {
 		ContentType: "application/json",
 		Params: []Param{
 			{Field: "type", Display: "Type", Type: "string", Default: "object", Options: []string{"object", "array"}, Description: "Type of JSON, object or array"},
-			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Description: "Number of rows in JSON array"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of rows in JSON array"},
 			{Field: "indent", Display: "Indent", Type: "bool", Default: "false", Description: "Whether or not to add indents and newlines"},
 			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
 		},
@@ -298,7 +321,7 @@ func rJsonNumber(f *Faker, v reflect.Value, tag string) error {
 		}
 	} else {
 		fName, fParams := parseNameAndParamsFromTag(tag)
-		info := GetFuncLookup(fName)
+		info := f.GetFuncLookup(fName)
 		if info == nil {
 			return fmt.Errorf("invalid function, %s does not exist", fName)
 		}
@@ -309,7 +332,7 @@ func rJsonNumber(f *Faker, v reflect.Value, tag string) error {
 			return err
 		}
 
-		valueIface, err := info.Generate(f, mapParams, info)
+		valueIface, err := f.callFuncLookup(fName, mapParams, info)
 		if err != nil {
 			return err
 		}
@@ -1,8 +1,12 @@
 package gofakeit
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -307,6 +311,47 @@ func TestJSONArrayLookupWithSubJSON(t *testing.T) {
 	}
 }
 
+func TestJSONFieldPattern(t *testing.T) {
+	value, err := New(11).JSON(&JSONOptions{
+		Type: "array",
+		Fields: []Field{
+			{Name: "status", Function: "{active:80|suspended:15|deleted:5}"},
+			{Name: "code", Function: "###-???"},
+		},
+		RowCount: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []struct {
+		Status string `json:"status"`
+		Code   string `json:"code"`
+	}
+	if err := json.Unmarshal(value, &rows); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range rows {
+		if row.Status != "active" && row.Status != "suspended" && row.Status != "deleted" {
+			t.Errorf("unexpected status %q", row.Status)
+		}
+		if len(row.Code) != 7 {
+			t.Errorf("unexpected code %q", row.Code)
+		}
+	}
+
+	// Plain names that are not functions still error
+	_, err = New(11).JSON(&JSONOptions{
+		Type:     "object",
+		Fields:   []Field{{Name: "bad", Function: "notafunction"}},
+		RowCount: 1,
+	})
+	if err == nil {
+		t.Fatal("expected error for unknown function")
+	}
+}
+
 func TestJSONNoType(t *testing.T) {
 	Seed(11)
 
@@ -580,3 +625,59 @@ func BenchmarkJSONLookup100(b *testing.B) {
 		}
 	}
 }
+
+func ExampleFaker_WriteNDJSON() {
+	f := New(11)
+
+	err := f.WriteNDJSON(context.Background(), os.Stdout, &JSONOptions{
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "last_name", Function: "lastname"},
+		},
+	}, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: {"id":1,"first_name":"Sonny","last_name":"Stiedemann"}
+	// {"id":2,"first_name":"Cody","last_name":"Donnelly"}
+	// {"id":3,"first_name":"Julius","last_name":"Farrell"}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var b bytes.Buffer
+	err := New(11).WriteNDJSON(context.Background(), &b, &JSONOptions{
+		RowCount: 25,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "person", Fields: []Field{
+				{Name: "name", Function: "name"},
+				{Name: "email", Function: "email"},
+			}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 25 {
+		t.Fatalf("expected 25 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		var row struct {
+			ID     int `json:"id"`
+			Person struct {
+				Name string `json:"name"`
+			} `json:"person"`
+		}
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Fatal(err)
+		}
+		if row.ID != i+1 || row.Person.Name == "" {
+			t.Errorf("unexpected row %s", line)
+		}
+	}
+}
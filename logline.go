@@ -0,0 +1,564 @@
+package gofakeit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogLineOptions defines values needed for web server access log generation
+type LogLineOptions struct {
+	Format    string    `json:"format" xml:"format" fake:"{randomstring:[common,combined,nginx,w3c,alb,json]}"` // common, combined, nginx, w3c, alb or json
+	LineCount int       `json:"line_count" xml:"line_count" fake:"{number:1,10}"`                               // Number of lines, defaults to 1
+	Start     time.Time `json:"start" xml:"start" fake:"skip"`                                                  // Time of the first request, defaults to a past date
+	Rate      float64   `json:"rate" xml:"rate" fake:"skip"`                                                    // Average requests per second, defaults to 10
+	Host      string    `json:"host" xml:"host" fake:"skip"`                                                    // Domain of the site, defaults to a random domain
+	NoHeader  bool      `json:"no_header" xml:"no_header" fake:"skip"`                                          // Skip the w3c directives before the first line
+}
+
+// LogLine generates web server access log lines in the apache common or combined, nginx, w3c extended,
+// aws alb or json format. Requests arrive at Rate per second, so each line is later than the last,
+// and their sizes and latencies follow their status.
+// A nil LogLineOptions returns randomly formatted lines.
+func LogLine(lo *LogLineOptions) (string, error) { return logLineFunc(GlobalFaker, lo) }
+
+// LogLine generates web server access log lines in the apache common or combined, nginx, w3c extended,
+// aws alb or json format. Requests arrive at Rate per second, so each line is later than the last,
+// and their sizes and latencies follow their status.
+// A nil LogLineOptions returns randomly formatted lines.
+func (f *Faker) LogLine(lo *LogLineOptions) (string, error) { return logLineFunc(f, lo) }
+
+// WriteLogLines streams access log lines to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each line.
+// A nil LogLineOptions writes randomly formatted lines.
+func WriteLogLines(ctx context.Context, w io.Writer, lo *LogLineOptions, progress WriteProgress) error {
+	return writeLogLines(ctx, GlobalFaker, w, lo, progress)
+}
+
+// WriteLogLines streams access log lines to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each line.
+// A nil LogLineOptions writes randomly formatted lines.
+func (f *Faker) WriteLogLines(ctx context.Context, w io.Writer, lo *LogLineOptions, progress WriteProgress) error {
+	return writeLogLines(ctx, f, w, lo, progress)
+}
+
+func logLineFunc(f *Faker, lo *LogLineOptions) (string, error) {
+	var sb strings.Builder
+	err := writeLogLines(context.Background(), f, &sb, lo, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func writeLogLines(ctx context.Context, f *Faker, w io.Writer, lo *LogLineOptions, progress WriteProgress) error {
+	if lo == nil {
+		// We didn't get a LogLineOptions, so create a new random one
+		err := f.Struct(&lo)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch lo.Format {
+	case "common", "combined", "nginx", "w3c", "alb", "json":
+	default:
+		return errors.New("invalid format, must be common, combined, nginx, w3c, alb or json")
+	}
+
+	lineCount := lo.LineCount
+	if lineCount <= 0 {
+		lineCount = 1
+	}
+
+	if lo.Rate < 0 {
+		return errors.New("rate must be greater than 0")
+	}
+	rate := lo.Rate
+	if rate == 0 {
+		rate = 10
+	}
+
+	site := newLogLineSite(f, lo)
+	now := lo.Start
+	if now.IsZero() {
+		now = pastDate(f)
+	}
+
+	bw := bufio.NewWriter(w)
+	if lo.Format == "w3c" && !lo.NoHeader {
+		_, err := bw.WriteString("#Software: Microsoft Internet Information Services 10.0\n" +
+			"#Version: 1.0\n" +
+			"#Date: " + now.UTC().Format("2006-01-02 15:04:05") + "\n" +
+			"#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query s-port cs-username c-ip " +
+			"cs(User-Agent) cs(Referer) sc-status sc-substatus sc-win32-status sc-bytes cs-bytes time-taken\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < lineCount; i++ {
+		if err := ctx.Err(); err != nil {
+			bw.Flush()
+			return err
+		}
+
+		if i > 0 {
+			now = now.Add(logLineGap(f, rate))
+		}
+
+		line, err := site.request(f, now).format(lo.Format, site)
+		if err != nil {
+			return err
+		}
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(i+1, lineCount)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// logLineGap will return the time until the next request. Gaps are exponential so requests
+// arrive at an average rate per second, and always at least a nanosecond so times keep increasing
+func logLineGap(f *Faker, rate float64) time.Duration {
+	gap := time.Duration(-math.Log(1-f.Float64()) / rate * float64(time.Second))
+	if gap <= 0 {
+		gap = time.Nanosecond
+	}
+
+	return gap
+}
+
+// logLineSite is the server every request in a log is made to
+type logLineSite struct {
+	host        string
+	serverIP    string
+	targetIP    string
+	elb         string
+	targetGroup string
+	certificate string
+}
+
+func newLogLineSite(f *Faker, lo *LogLineOptions) *logLineSite {
+	host := lo.Host
+	if host == "" {
+		host = "www." + domainName(f)
+	}
+
+	region := randomString(f, []string{"us-east-1", "us-east-2", "us-west-2", "eu-west-1", "eu-central-1", "ap-southeast-2"})
+	account := replaceWithNumbers(f, "############")
+	name := logLineSlug(f, 1)
+
+	return &logLineSite{
+		host:        host,
+		serverIP:    fmt.Sprintf("10.0.%d.%d", number(f, 0, 255), number(f, 1, 254)),
+		targetIP:    fmt.Sprintf("10.0.%d.%d", number(f, 0, 255), number(f, 1, 254)),
+		elb:         "app/" + name + "-lb/" + hexUint(f, 64)[2:],
+		targetGroup: "arn:aws:elasticloadbalancing:" + region + ":" + account + ":targetgroup/" + name + "-targets/" + hexUint(f, 64)[2:],
+		certificate: "arn:aws:acm:" + region + ":" + account + ":certificate/" + uuid(f),
+	}
+}
+
+// logLineRoute is a path requests are made to
+type logLineRoute struct {
+	path   string
+	kind   string
+	weight float32
+}
+
+// logLineRoutes are the paths requests are made to and how often. The kind of
+// route sets its methods and the size and latency of its responses
+var logLineRoutes = []logLineRoute{
+	{"/", "page", 8},
+	{"/about", "page", 1},
+	{"/contact", "page", 1},
+	{"/search?q={word}", "page", 3},
+	{"/products", "page", 4},
+	{"/products/{id}", "page", 6},
+	{"/products/{id}/reviews", "page", 2},
+	{"/category/{word}", "page", 3},
+	{"/blog/{slug}", "page", 2},
+	{"/login", "form", 2},
+	{"/cart", "form", 2},
+	{"/checkout", "form", 1},
+	{"/api/v1/products?page={page}", "api", 4},
+	{"/api/v1/products/{id}", "api", 4},
+	{"/api/v1/users/{id}", "api", 3},
+	{"/api/v1/orders", "api", 2},
+	{"/api/v1/orders/{id}", "api", 2},
+	{"/static/css/{word}.css", "static", 6},
+	{"/static/js/{word}.js", "static", 6},
+	{"/images/{word}.jpg", "static", 8},
+	{"/images/{word}.png", "static", 4},
+	{"/favicon.ico", "static", 3},
+	{"/robots.txt", "static", 1},
+	{"/health", "health", 2},
+}
+
+// logLineRequest is a single request and its response
+type logLineRequest struct {
+	time      time.Time
+	clientIP  string
+	port      int
+	user      string
+	method    string
+	path      string
+	protocol  string
+	status    int
+	bytes     int
+	received  int
+	latency   time.Duration
+	referer   string
+	userAgent string
+	traceID   string
+}
+
+// request will generate a request to a route of the site with a status, size and latency to match
+func (s *logLineSite) request(f *Faker, t time.Time) *logLineRequest {
+	route := logLinePickRoute(f, nil)
+
+	r := &logLineRequest{
+		time:      t,
+		clientIP:  ipv4Address(f),
+		port:      number(f, 1024, 65535),
+		user:      "-",
+		method:    "GET",
+		path:      logLinePath(f, route.path),
+		protocol:  logLinePick(f, []string{"HTTP/1.1", "HTTP/2.0", "HTTP/1.0"}, []float32{70, 28, 2}),
+		referer:   "-",
+		userAgent: userAgent(f),
+		traceID:   fmt.Sprintf("Root=1-%08x-%s", t.Unix(), hexUint(f, 96)[2:]),
+	}
+
+	switch route.kind {
+	case "form":
+		r.method = logLinePick(f, []string{"GET", "POST"}, []float32{60, 40})
+	case "api":
+		r.method = logLinePick(f, []string{"GET", "POST", "PUT", "PATCH", "DELETE"}, []float32{60, 20, 10, 5, 5})
+	default:
+		r.method = logLinePick(f, []string{"GET", "HEAD"}, []float32{98, 2})
+	}
+
+	// Logged in users
+	if route.kind != "static" && f.Float64() < 0.1 {
+		r.user = username(f)
+	}
+
+	// Pages are linked from other sites, assets from the page that loaded them
+	switch route.kind {
+	case "static", "form":
+		r.referer = "https://" + s.host + "/"
+	case "page":
+		r.referer = logLinePick(f, []string{"-", "https://" + s.host + "/", url(f)}, []float32{50, 25, 25})
+	}
+
+	r.status = logLineStatus(f, route.kind, r.method)
+	r.received = number(f, 200, 900)
+	if r.method == "POST" || r.method == "PUT" || r.method == "PATCH" {
+		r.received += number(f, 50, 4000)
+	}
+
+	// Response size follows the status and kind of route
+	switch {
+	case r.method == "HEAD" || r.status == 204 || r.status == 304:
+		r.bytes = 0
+	case r.status >= 500:
+		r.bytes = number(f, 150, 600)
+	case r.status >= 400:
+		r.bytes = number(f, 150, 1500)
+	case r.status >= 300:
+		r.bytes = number(f, 150, 400)
+	case route.kind == "page" || route.kind == "form":
+		r.bytes = number(f, 5000, 80000)
+	case route.kind == "static":
+		r.bytes = number(f, 1000, 300000)
+	case route.kind == "api":
+		r.bytes = number(f, 80, 8000)
+	default:
+		r.bytes = number(f, 2, 50)
+	}
+
+	// Latency in milliseconds follows the status and kind of route plus the time to send the response
+	ms := logLineLatency(f, r.status, route.kind)
+	ms += float64(r.bytes) / f.Float64Range(2000, 20000) // 2 to 20 MB/s
+	r.latency = time.Duration(ms * float64(time.Millisecond))
+
+	return r
+}
+
+// logLinePickRoute will pick a weighted route of one of the kinds, or any route if kinds is empty
+func logLinePickRoute(f *Faker, kinds []string) logLineRoute {
+	var routes []any
+	var weights []float32
+	for i, route := range logLineRoutes {
+		if len(kinds) == 0 || slices.Contains(kinds, route.kind) {
+			routes = append(routes, i)
+			weights = append(weights, route.weight)
+		}
+	}
+
+	i, _ := weighted(f, routes, weights)
+	return logLineRoutes[i.(int)]
+}
+
+// logLineLatency will return the milliseconds a server takes to answer with a status
+// for a kind of route, not counting the time to send the response
+func logLineLatency(f *Faker, status int, kind string) float64 {
+	switch {
+	case status == 504:
+		return f.Float64Range(30000, 60000)
+	case status == 502 || status == 503:
+		return f.Float64Range(0.5, 50)
+	case status >= 500:
+		return f.Float64Range(50, 2500)
+	case status >= 400 || status == 304:
+		return f.Float64Range(0.2, 60)
+	case status >= 300:
+		return f.Float64Range(1, 30)
+	case kind == "static":
+		return f.Float64Range(0.2, 15)
+	case kind == "health":
+		return f.Float64Range(0.1, 3)
+	case kind == "api":
+		return f.Float64Range(5, 400)
+	default:
+		return f.Float64Range(15, 300)
+	}
+}
+
+// logLineStatus will pick a status code for a request, mostly successful with errors to match the kind of route
+func logLineStatus(f *Faker, kind string, method string) int {
+	success := 200
+	switch {
+	case kind == "api" && method == "POST":
+		success = 201
+	case method == "DELETE":
+		success = 204
+	case kind == "form" && method == "POST":
+		success = 302 // Redirect after post
+	}
+
+	statuses := []any{success, 301, 304, 400, 401, 403, 404, 429, 500, 502, 503, 504}
+	weights := []float32{88, 0.5, 0, 0.5, 0.3, 0.3, 2, 0, 0.8, 0.3, 0.3, 0.2}
+
+	switch kind {
+	case "static":
+		weights[2] = 20 // Not modified
+		weights[6] = 3
+	case "page":
+		weights[2] = 4
+		weights[6] = 3
+	case "api":
+		weights[3] = 3
+		weights[4] = 2
+		weights[6] = 3
+		weights[7] = 1
+	case "form":
+		weights[3] = 2
+		weights[4] = 2
+	}
+
+	// Only cacheable requests are not modified
+	if method != "GET" {
+		weights[2] = 0
+	}
+
+	status, _ := weighted(f, statuses, weights)
+	return status.(int)
+}
+
+// format will write a request as a line in the log format
+func (r *logLineRequest) format(format string, s *logLineSite) (string, error) {
+	request := r.method + " " + r.path + " " + r.protocol
+	switch format {
+	case "common":
+		return fmt.Sprintf(`%s - %s [%s] "%s" %d %s`,
+			r.clientIP, r.user, r.time.Format("02/Jan/2006:15:04:05 -0700"), request, r.status, logLineBytes(r.bytes)), nil
+	case "combined":
+		return fmt.Sprintf(`%s - %s [%s] "%s" %d %s "%s" "%s"`,
+			r.clientIP, r.user, r.time.Format("02/Jan/2006:15:04:05 -0700"), request, r.status, logLineBytes(r.bytes),
+			logLineQuote(r.referer), logLineQuote(r.userAgent)), nil
+	case "nginx":
+		// The main format from the default nginx.conf, body bytes are 0 rather than -
+		return fmt.Sprintf(`%s - %s [%s] "%s" %d %d "%s" "%s" "-"`,
+			r.clientIP, r.user, r.time.Format("02/Jan/2006:15:04:05 -0700"), request, r.status, r.bytes,
+			logLineQuote(r.referer), logLineQuote(r.userAgent)), nil
+	case "w3c":
+		stem, query, ok := strings.Cut(r.path, "?")
+		if !ok {
+			query = "-"
+		}
+		return fmt.Sprintf("%s %s %s %s %s 443 %s %s %s %s %d 0 0 %d %d %d",
+			r.time.UTC().Format("2006-01-02 15:04:05"), s.serverIP, r.method, stem, query, r.user, r.clientIP,
+			strings.ReplaceAll(r.userAgent, " ", "+"), r.referer, r.status, r.bytes, r.received, r.latency.Milliseconds()), nil
+	case "alb":
+		target, targetStatus, targetTime := s.targetIP+":80", strconv.Itoa(r.status), fmt.Sprintf("%.3f", r.latency.Seconds())
+		if r.status == 502 || r.status == 503 || r.status == 504 {
+			// The load balancer answered without a response from a target
+			targetStatus, targetTime = "-", "-1"
+		}
+		created := r.time.Add(-r.latency).UTC().Format("2006-01-02T15:04:05.000000Z")
+		return fmt.Sprintf(`https %s %s %s:%d %s 0.000 %s 0.000 %d %s %d %d "%s https://%s:443%s %s" "%s" `+
+			`ECDHE-RSA-AES128-GCM-SHA256 TLSv1.2 %s "%s" "%s" "%s" 0 %s "forward" "-" "-" "%s" "%s" "-" "-"`,
+			r.time.UTC().Format("2006-01-02T15:04:05.000000Z"), s.elb, r.clientIP, r.port, target, targetTime,
+			r.status, targetStatus, r.received, r.bytes, r.method, s.host, r.path, r.protocol, logLineQuote(r.userAgent),
+			s.targetGroup, r.traceID, s.host, s.certificate, created, target, targetStatus), nil
+	case "json":
+		entry := struct {
+			Time       string  `json:"time"`
+			RemoteAddr string  `json:"remote_addr"`
+			RemoteUser string  `json:"remote_user"`
+			Host       string  `json:"host"`
+			Method     string  `json:"method"`
+			Path       string  `json:"path"`
+			Protocol   string  `json:"protocol"`
+			Status     int     `json:"status"`
+			Bytes      int     `json:"bytes"`
+			LatencyMS  float64 `json:"latency_ms"`
+			Referer    string  `json:"referer"`
+			UserAgent  string  `json:"user_agent"`
+		}{
+			Time:       r.time.Format(time.RFC3339Nano),
+			RemoteAddr: r.clientIP,
+			RemoteUser: strings.TrimPrefix(r.user, "-"),
+			Host:       s.host,
+			Method:     r.method,
+			Path:       r.path,
+			Protocol:   r.protocol,
+			Status:     r.status,
+			Bytes:      r.bytes,
+			LatencyMS:  math.Round(float64(r.latency.Microseconds())) / 1000,
+			Referer:    strings.TrimPrefix(r.referer, "-"),
+			UserAgent:  r.userAgent,
+		}
+
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	return "", errors.New("invalid format, must be common, combined, nginx, w3c, alb or json")
+}
+
+// logLinePath will fill in the placeholders of a route path
+func logLinePath(f *Faker, path string) string {
+	for strings.Contains(path, "{") {
+		switch {
+		case strings.Contains(path, "{id}"):
+			path = strings.Replace(path, "{id}", strconv.Itoa(number(f, 1, 99999)), 1)
+		case strings.Contains(path, "{page}"):
+			path = strings.Replace(path, "{page}", strconv.Itoa(number(f, 1, 20)), 1)
+		case strings.Contains(path, "{slug}"):
+			path = strings.Replace(path, "{slug}", logLineSlug(f, 3), 1)
+		case strings.Contains(path, "{word}"):
+			path = strings.Replace(path, "{word}", logLineSlug(f, 1), 1)
+		default:
+			return path
+		}
+	}
+
+	return path
+}
+
+// logLineSlug will join lowercase nouns with dashes for use in a path
+func logLineSlug(f *Faker, count int) string {
+	words := make([]string, count)
+	for i := range words {
+		words[i] = strings.ToLower(strings.ReplaceAll(noun(f), " ", "-"))
+	}
+
+	return strings.Join(words, "-")
+}
+
+// logLinePick will pick a weighted option
+func logLinePick(f *Faker, options []string, weights []float32) string {
+	values := make([]any, len(options))
+	for i, option := range options {
+		values[i] = option
+	}
+
+	value, _ := weighted(f, values, weights)
+	return value.(string)
+}
+
+// logLineBytes will return the response size or - for an empty response like apache
+func logLineBytes(bytes int) string {
+	if bytes == 0 {
+		return "-"
+	}
+
+	return strconv.Itoa(bytes)
+}
+
+// logLineQuote will escape quotes and backslashes in a quoted log value
+func logLineQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func addLogLineLookup() {
+	AddFuncLookup("logline", Info{
+		Display:     "Log Line",
+		Category:    "internet",
+		Description: "Web server access log lines for requests arriving at a steady rate, in apache, nginx, w3c, aws alb or json format",
+		Example:     `203.0.113.42 - - [02/Jan/2006:15:04:05 +0000] "GET /products/4821 HTTP/1.1" 200 18342 "-" "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"`,
+		Output:      "string",
+		ContentType: "text/plain",
+		Params: []Param{
+			{Field: "format", Display: "Format", Type: "string", Default: "combined", Options: []string{"common", "combined", "nginx", "w3c", "alb", "json"}, Description: "Access log format"},
+			{Field: "linecount", Display: "Line Count", Type: "int", Default: "1", Description: "Number of lines"},
+			{Field: "rate", Display: "Rate", Type: "float", Default: "10", Description: "Average requests per second"},
+			{Field: "host", Display: "Host", Type: "string", Optional: true, Description: "Domain of the site"},
+			{Field: "noheader", Display: "No Header", Type: "bool", Default: "false", Description: "Skip the w3c directives before the first line"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			lo := LogLineOptions{}
+
+			format, err := info.GetString(m, "format")
+			if err != nil {
+				return nil, err
+			}
+			lo.Format = format
+
+			lineCount, err := info.GetInt(m, "linecount")
+			if err != nil {
+				return nil, err
+			}
+			lo.LineCount = lineCount
+
+			rate, err := info.GetFloat64(m, "rate")
+			if err != nil {
+				return nil, err
+			}
+			lo.Rate = rate
+
+			lo.Host, _ = info.GetString(m, "host")
+
+			noHeader, err := info.GetBool(m, "noheader")
+			if err != nil {
+				return nil, err
+			}
+			lo.NoHeader = noHeader
+
+			return logLineFunc(f, &lo)
+		},
+	})
+}
@@ -0,0 +1,277 @@
+package gofakeit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleLogLine() {
+	Seed(11)
+
+	value, err := LogLine(&LogLineOptions{
+		Format:    "common",
+		LineCount: 3,
+		Start:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Rate:      0.5,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: 162.195.161.54 - - [01/Mar/2024:12:00:00 +0000] "GET /images/outfit.jpg HTTP/2.0" 200 274510
+	// 45.87.90.166 - - [01/Mar/2024:12:00:03 +0000] "GET /images/troop.jpg HTTP/1.1" 200 136334
+	// 125.230.123.116 - - [01/Mar/2024:12:00:04 +0000] "GET /static/css/musician.css HTTP/1.1" 200 24444
+}
+
+func ExampleFaker_LogLine() {
+	f := New(11)
+
+	value, err := f.LogLine(&LogLineOptions{
+		Format:    "common",
+		LineCount: 3,
+		Start:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Rate:      0.5,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: 162.195.161.54 - - [01/Mar/2024:12:00:00 +0000] "GET /images/outfit.jpg HTTP/2.0" 200 274510
+	// 45.87.90.166 - - [01/Mar/2024:12:00:03 +0000] "GET /images/troop.jpg HTTP/1.1" 200 136334
+	// 125.230.123.116 - - [01/Mar/2024:12:00:04 +0000] "GET /static/css/musician.css HTTP/1.1" 200 24444
+}
+
+func TestLogLineFormats(t *testing.T) {
+	patterns := map[string]*regexp.Regexp{
+		"common":   regexp.MustCompile(`^[\d.]+ - \S+ \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "[A-Z]+ /\S* HTTP/[\d.]+" \d{3} (\d+|-)$`),
+		"combined": regexp.MustCompile(`^[\d.]+ - \S+ \[[^\]]+\] "[A-Z]+ /\S* HTTP/[\d.]+" \d{3} (\d+|-) "[^"]*" "[^"]*"$`),
+		"nginx":    regexp.MustCompile(`^[\d.]+ - \S+ \[[^\]]+\] "[A-Z]+ /\S* HTTP/[\d.]+" \d{3} \d+ "[^"]*" "[^"]*" "-"$`),
+		"w3c":      regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} [\d.]+ [A-Z]+ /\S* \S+ 443 \S+ [\d.]+ \S+ \S+ \d{3} 0 0 \d+ \d+ \d+$`),
+		"alb":      regexp.MustCompile(`^https \S+Z app/\S+ [\d.]+:\d+ [\d.]+:80 0\.000 (-1|[\d.]+) 0\.000 \d{3} (\d{3}|-) \d+ \d+ "[A-Z]+ https://\S+:443/\S* HTTP/[\d.]+" "[^"]*" \S+ TLSv1\.2 arn:aws:elasticloadbalancing:\S+ "Root=1-[0-9a-f]{8}-[0-9a-f]{24}" "\S+" "arn:aws:acm:\S+" 0 \S+Z "forward" "-" "-" "[\d.:]+" "(\d{3}|-)" "-" "-"$`),
+	}
+
+	for format, pattern := range patterns {
+		value, err := New(11).LogLine(&LogLineOptions{Format: format, LineCount: 200, NoHeader: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(value, "\n")
+		if len(lines) != 200 {
+			t.Errorf("%s: expected 200 lines, got %d", format, len(lines))
+		}
+		for _, line := range lines {
+			if !pattern.MatchString(line) {
+				t.Errorf("%s: unexpected line %s", format, line)
+				break
+			}
+		}
+	}
+
+	// W3c starts with its directives unless they are skipped
+	value, err := New(11).LogLine(&LogLineOptions{Format: "w3c", LineCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(value, "\n")
+	if len(lines) != 6 || lines[1] != "#Version: 1.0" || !strings.HasPrefix(lines[3], "#Fields: date time") {
+		t.Errorf("unexpected w3c directives %s", value)
+	}
+}
+
+type logLineTestEntry struct {
+	Time      time.Time `json:"time"`
+	Path      string    `json:"path"`
+	Method    string    `json:"method"`
+	Status    int       `json:"status"`
+	Bytes     int       `json:"bytes"`
+	LatencyMS float64   `json:"latency_ms"`
+}
+
+func logLineTestEntries(t *testing.T, lo *LogLineOptions) []logLineTestEntry {
+	lo.Format = "json"
+	value, err := New(11).LogLine(lo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []logLineTestEntry
+	for _, line := range strings.Split(value, "\n") {
+		var entry logLineTestEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestLogLineRate(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := logLineTestEntries(t, &LogLineOptions{LineCount: 2000, Start: start, Rate: 20})
+
+	if !entries[0].Time.Equal(start) {
+		t.Errorf("expected first line at %s, got %s", start, entries[0].Time)
+	}
+	for i := 1; i < len(entries); i++ {
+		if !entries[i].Time.After(entries[i-1].Time) {
+			t.Fatalf("expected line %d after %s, got %s", i, entries[i-1].Time, entries[i].Time)
+		}
+	}
+
+	// 2000 requests at 20 per second take about 100 seconds
+	if span := entries[len(entries)-1].Time.Sub(start); span < 85*time.Second || span > 115*time.Second {
+		t.Errorf("expected about 100 seconds of requests, got %s", span)
+	}
+}
+
+func TestLogLineStatus(t *testing.T) {
+	entries := logLineTestEntries(t, &LogLineOptions{LineCount: 5000})
+
+	counts := map[int]int{}
+	var okBytes, errBytes, okCount, errCount int
+	for _, e := range entries {
+		counts[e.Status]++
+
+		switch {
+		case e.Status == 204 || e.Status == 304 || e.Method == "HEAD":
+			if e.Bytes != 0 {
+				t.Errorf("expected no bytes for %d %s, got %d", e.Status, e.Method, e.Bytes)
+			}
+		case e.Status == 200:
+			okBytes += e.Bytes
+			okCount++
+		case e.Status >= 500:
+			errBytes += e.Bytes
+			errCount++
+		}
+
+		if e.Status == 504 && e.LatencyMS < 30000 {
+			t.Errorf("expected a gateway timeout to take at least 30s, got %vms", e.LatencyMS)
+		}
+		if e.Status == 304 && e.LatencyMS > 100 {
+			t.Errorf("expected not modified to be fast, got %vms", e.LatencyMS)
+		}
+		if strings.HasPrefix(e.Path, "/static/") && e.Method != "GET" && e.Method != "HEAD" {
+			t.Errorf("expected static files to only be read, got %s %s", e.Method, e.Path)
+		}
+	}
+
+	if counts[200] < 3500 {
+		t.Errorf("expected mostly 200 responses, got %d", counts[200])
+	}
+	if counts[404] == 0 || counts[304] == 0 || errCount == 0 {
+		t.Errorf("expected some 404, 304 and 5xx responses, got %v", counts)
+	}
+	if okBytes/okCount < 10*(errBytes/errCount) {
+		t.Errorf("expected successful responses to be larger than errors, got %d and %d", okBytes/okCount, errBytes/errCount)
+	}
+}
+
+func TestWriteLogLines(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	calls := 0
+	err := New(11).WriteLogLines(context.Background(), &buf, &LogLineOptions{Format: "combined", LineCount: 50, Start: start}, func(written, total int) {
+		calls++
+		if written != calls || total != 50 {
+			t.Errorf("unexpected progress %d of %d", written, total)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 50 || strings.Count(buf.String(), "\n") != 50 {
+		t.Errorf("expected 50 lines and progress calls, got %d and %d", strings.Count(buf.String(), "\n"), calls)
+	}
+
+	// Matches LogLine with the same seed
+	value, err := New(11).LogLine(&LogLineOptions{Format: "combined", LineCount: 50, Start: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != value+"\n" {
+		t.Error("expected WriteLogLines to match LogLine")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = New(11).WriteLogLines(ctx, &bytes.Buffer{}, &LogLineOptions{Format: "json", LineCount: 10}, nil)
+	if err != context.Canceled {
+		t.Errorf("expected context canceled, got %v", err)
+	}
+
+	// Defaults are not written back to the options
+	lo := &LogLineOptions{Format: "w3c"}
+	err = New(11).WriteLogLines(context.Background(), io.Discard, lo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lo.LineCount != 0 || lo.Rate != 0 {
+		t.Errorf("expected options to be left as is, got %+v", lo)
+	}
+	if err := New(11).WriteLogLines(context.Background(), &errWriter{written: new(int)}, lo, nil); err == nil {
+		t.Error("expected writer error")
+	}
+}
+
+func TestLogLineErrors(t *testing.T) {
+	if _, err := New(11).LogLine(&LogLineOptions{Format: "iis"}); err == nil {
+		t.Error("expected error for invalid format")
+	}
+	if _, err := New(11).LogLine(&LogLineOptions{Format: "json", Rate: -1}); err == nil {
+		t.Error("expected error for negative rate")
+	}
+}
+
+func TestLogLineNil(t *testing.T) {
+	value, err := New(11).LogLine(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == "" {
+		t.Error("expected log lines")
+	}
+}
+
+func TestLogLineLookup(t *testing.T) {
+	info := GetFuncLookup("logline")
+
+	m := MapParams{
+		"format":    {"nginx"},
+		"linecount": {"5"},
+		"host":      {"shop.example.com"},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(value.(string), "\n")
+	if len(lines) != 5 || !strings.HasSuffix(lines[0], `"-"`) {
+		t.Errorf("unexpected log lines %s", value)
+	}
+}
+
+func BenchmarkLogLine(b *testing.B) {
+	f := New(11)
+	lo := &LogLineOptions{Format: "combined", LineCount: 100}
+
+	for i := 0; i < b.N; i++ {
+		f.LogLine(lo)
+	}
+}
@@ -0,0 +1,375 @@
+package gofakeit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// LogRecordOptions defines values needed for application log record generation
+type LogRecordOptions struct {
+	Format    string    `json:"format" xml:"format" fake:"{randomstring:[rfc3164,rfc5424,logfmt,json]}"` // rfc3164, rfc5424, logfmt or json
+	LineCount int       `json:"line_count" xml:"line_count" fake:"{number:1,10}"`                        // Number of records, defaults to 1
+	Start     time.Time `json:"start" xml:"start" fake:"skip"`                                           // Time of the first record, defaults to a past date
+	Rate      float64   `json:"rate" xml:"rate" fake:"skip"`                                             // Average records per second, defaults to 10
+	Hostname  string    `json:"hostname" xml:"hostname" fake:"skip"`                                     // Host logging the records, defaults to a random hostname
+	AppName   string    `json:"app_name" xml:"app_name" fake:"skip"`                                     // App logging the records, defaults to a random name
+}
+
+// LogRecord generates application log records in the rfc3164 or rfc5424 syslog, logfmt or json lines format.
+// Each record has a level, message id, message from the error or hacker data and request attributes,
+// written as structured data in rfc5424, from one host, app and pid.
+// A nil LogRecordOptions returns randomly formatted records.
+func LogRecord(lo *LogRecordOptions) (string, error) { return logRecordFunc(GlobalFaker, lo) }
+
+// LogRecord generates application log records in the rfc3164 or rfc5424 syslog, logfmt or json lines format.
+// Each record has a level, message id, message from the error or hacker data and request attributes,
+// written as structured data in rfc5424, from one host, app and pid.
+// A nil LogRecordOptions returns randomly formatted records.
+func (f *Faker) LogRecord(lo *LogRecordOptions) (string, error) { return logRecordFunc(f, lo) }
+
+// WriteLogRecords streams application log records to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each record.
+// A nil LogRecordOptions writes randomly formatted records.
+func WriteLogRecords(ctx context.Context, w io.Writer, lo *LogRecordOptions, progress WriteProgress) error {
+	return writeLogRecords(ctx, GlobalFaker, w, lo, progress)
+}
+
+// WriteLogRecords streams application log records to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each record.
+// A nil LogRecordOptions writes randomly formatted records.
+func (f *Faker) WriteLogRecords(ctx context.Context, w io.Writer, lo *LogRecordOptions, progress WriteProgress) error {
+	return writeLogRecords(ctx, f, w, lo, progress)
+}
+
+// SlogRecord generates a slog.Record with a level, message and attributes like an application would log
+func SlogRecord() slog.Record { return slogRecord(GlobalFaker) }
+
+// SlogRecord generates a slog.Record with a level, message and attributes like an application would log
+func (f *Faker) SlogRecord() slog.Record { return slogRecord(f) }
+
+// WriteSlogRecords sends LineCount records to h, with the host, app and pid added with WithAttrs,
+// to load test a slog.Handler. Records h is not enabled for are skipped like slog.Logger would.
+// Format is ignored and sending stops when ctx is cancelled or h returns an error.
+func WriteSlogRecords(ctx context.Context, h slog.Handler, lo *LogRecordOptions, progress WriteProgress) error {
+	return writeSlogRecords(ctx, GlobalFaker, h, lo, progress)
+}
+
+// WriteSlogRecords sends LineCount records to h, with the host, app and pid added with WithAttrs,
+// to load test a slog.Handler. Records h is not enabled for are skipped like slog.Logger would.
+// Format is ignored and sending stops when ctx is cancelled or h returns an error.
+func (f *Faker) WriteSlogRecords(ctx context.Context, h slog.Handler, lo *LogRecordOptions, progress WriteProgress) error {
+	return writeSlogRecords(ctx, f, h, lo, progress)
+}
+
+func logRecordFunc(f *Faker, lo *LogRecordOptions) (string, error) {
+	var sb strings.Builder
+	err := writeLogRecords(context.Background(), f, &sb, lo, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func writeLogRecords(ctx context.Context, f *Faker, w io.Writer, lo *LogRecordOptions, progress WriteProgress) error {
+	if lo == nil {
+		// We didn't get a LogRecordOptions, so create a new random one
+		err := f.Struct(&lo)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch lo.Format {
+	case "rfc3164", "rfc5424", "logfmt", "json":
+	default:
+		return errors.New("invalid format, must be rfc3164, rfc5424, logfmt or json")
+	}
+
+	source, err := newLogRecordSource(f, lo)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	// Logfmt and json lines are written by the slog handlers
+	var h slog.Handler
+	switch lo.Format {
+	case "logfmt":
+		h = slog.NewTextHandler(bw, &slog.HandlerOptions{Level: slog.LevelDebug}).WithAttrs(source.attrs())
+	case "json":
+		h = slog.NewJSONHandler(bw, &slog.HandlerOptions{Level: slog.LevelDebug}).WithAttrs(source.attrs())
+	}
+
+	err = source.records(ctx, f, progress, func(r *logRecord) error {
+		if h != nil {
+			return h.Handle(ctx, r.slogRecord())
+		}
+
+		if _, err := bw.WriteString(r.syslog(lo.Format, source)); err != nil {
+			return err
+		}
+		return bw.WriteByte('\n')
+	})
+	if err != nil {
+		bw.Flush()
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func writeSlogRecords(ctx context.Context, f *Faker, h slog.Handler, lo *LogRecordOptions, progress WriteProgress) error {
+	if h == nil {
+		return errors.New("must pass a slog handler")
+	}
+
+	if lo == nil {
+		lo = &LogRecordOptions{}
+	}
+
+	source, err := newLogRecordSource(f, lo)
+	if err != nil {
+		return err
+	}
+
+	// The source is added once like a logger made with With
+	h = h.WithAttrs(source.attrs())
+
+	return source.records(ctx, f, progress, func(r *logRecord) error {
+		if !h.Enabled(ctx, logRecordLevel(r.severity)) {
+			return nil
+		}
+
+		return h.Handle(ctx, r.slogRecord())
+	})
+}
+
+func slogRecord(f *Faker) slog.Record {
+	source, _ := newLogRecordSource(f, &LogRecordOptions{})
+	r := source.record(f, pastDate(f)).slogRecord()
+	r.AddAttrs(source.attrs()...)
+
+	return r
+}
+
+// logRecordSource is the host, app and process every record in a log comes from
+type logRecordSource struct {
+	hostname string
+	appName  string
+	pid      int
+	facility int
+
+	lineCount int
+	rate      float64
+	start     time.Time
+}
+
+// newLogRecordSource will pick the host, app and pid for the records, and the count and rate
+// they are written at with defaults for any not set in the options
+func newLogRecordSource(f *Faker, lo *LogRecordOptions) (*logRecordSource, error) {
+	if lo.Rate < 0 {
+		return nil, errors.New("rate must be greater than 0")
+	}
+
+	s := &logRecordSource{
+		hostname:  lo.Hostname,
+		appName:   lo.AppName,
+		pid:       number(f, 100, 65535),
+		facility:  randomInt(f, []int{1, 3, 16, 17, 18, 19, 20, 21, 22, 23}), // user, daemon or local0 to local7
+		lineCount: lo.LineCount,
+		rate:      lo.Rate,
+		start:     lo.Start,
+	}
+
+	if s.lineCount <= 0 {
+		s.lineCount = 1
+	}
+	if s.rate == 0 {
+		s.rate = 10
+	}
+
+	if s.hostname == "" {
+		s.hostname = fmt.Sprintf("%s-%02d", randomString(f, []string{"web", "api", "worker", "db", "cache", "auth"}), number(f, 1, 20))
+	}
+	if s.appName == "" {
+		s.appName = logLineSlug(f, 1) + "-" + randomString(f, []string{"service", "api", "worker", "daemon"})
+	}
+
+	return s, nil
+}
+
+// records will call handle with lineCount records arriving at rate per second
+func (s *logRecordSource) records(ctx context.Context, f *Faker, progress WriteProgress, handle func(*logRecord) error) error {
+	now := s.start
+	if now.IsZero() {
+		now = pastDate(f)
+	}
+
+	for i := 0; i < s.lineCount; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if i > 0 {
+			now = now.Add(logLineGap(f, s.rate))
+		}
+
+		err := handle(s.record(f, now))
+		if err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(i+1, s.lineCount)
+		}
+	}
+
+	return nil
+}
+
+// attrs will return the host, app and pid as slog attributes
+func (s *logRecordSource) attrs() []slog.Attr {
+	return []slog.Attr{slog.String("host", s.hostname), slog.String("app", s.appName), slog.Int("pid", s.pid)}
+}
+
+// logRecord is a single application log record
+type logRecord struct {
+	time     time.Time
+	severity int // Syslog severity, 0 emergency to 7 debug
+	msgID    string
+	message  string
+	attrs    []slog.Attr
+}
+
+// record will generate a record, mostly info and debug messages with errors from the error data
+func (s *logRecordSource) record(f *Faker, t time.Time) *logRecord {
+	severity, _ := weighted(f, []any{0, 1, 2, 3, 4, 5, 6, 7}, []float32{0.1, 0.2, 0.7, 5, 10, 8, 60, 16})
+	r := &logRecord{time: t, severity: severity.(int)}
+
+	// Errors have a message id for where they happened
+	if r.severity <= 3 {
+		switch number(f, 0, 4) {
+		case 0:
+			r.msgID, r.message = "DB", errorDatabase(f).Error()
+		case 1:
+			r.msgID, r.message = "GRPC", errorGRPC(f).Error()
+		case 2:
+			r.msgID, r.message = "HTTP", errorHTTP(f).Error()
+		case 3:
+			r.msgID, r.message = "RUNTIME", errorRuntime(f).Error()
+		default:
+			r.msgID, r.message = "VALIDATION", errorValidation(f).Error()
+		}
+	} else {
+		r.msgID = randomString(f, []string{"REQ", "JOB", "CACHE", "AUTH", "SYNC"})
+		r.message = hackerPhrase(f)
+	}
+
+	r.attrs = append(r.attrs, slog.String("msg_id", r.msgID), slog.String("request_id", uuid(f)))
+	if f.Float64() < 0.4 {
+		r.attrs = append(r.attrs, slog.String("user", username(f)))
+	}
+	if r.severity > 3 && f.Float64() < 0.5 {
+		r.attrs = append(r.attrs, slog.Int("duration_ms", number(f, 1, 2000)))
+	}
+
+	return r
+}
+
+// slogRecord will convert a record into a slog.Record with its attributes
+func (r *logRecord) slogRecord() slog.Record {
+	record := slog.NewRecord(r.time, logRecordLevel(r.severity), r.message, 0)
+	record.AddAttrs(r.attrs...)
+
+	return record
+}
+
+// syslog will format a record as an rfc3164 or rfc5424 syslog message
+func (r *logRecord) syslog(format string, s *logRecordSource) string {
+	pri := s.facility*8 + r.severity
+
+	if format == "rfc3164" {
+		return fmt.Sprintf("<%d>%s %s %s[%d]: %s", pri, r.time.Format("Jan _2 15:04:05"), s.hostname, s.appName, s.pid, r.message)
+	}
+
+	// Attributes other than the message id are structured data with a private id
+	var sd strings.Builder
+	sd.WriteString("[request@32473")
+	for _, attr := range r.attrs {
+		if attr.Key == "msg_id" {
+			continue
+		}
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(attr.Value.String())
+		sd.WriteString(" " + attr.Key + `="` + value + `"`)
+	}
+	sd.WriteString("]")
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s", pri, r.time.Format("2006-01-02T15:04:05.000000Z07:00"),
+		s.hostname, s.appName, s.pid, r.msgID, sd.String(), r.message)
+}
+
+// logRecordLevel will convert a syslog severity into the closest slog level
+func logRecordLevel(severity int) slog.Level {
+	switch {
+	case severity <= 3:
+		return slog.LevelError
+	case severity == 4:
+		return slog.LevelWarn
+	case severity <= 6:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+func addLogRecordLookup() {
+	AddFuncLookup("logrecord", Info{
+		Display:     "Log Record",
+		Category:    "internet",
+		Description: "Application log records with a level, message id, message and request attributes, in syslog, logfmt or json lines format",
+		Example:     `<182>1 2024-03-01T12:00:00.000000Z api-05 crowd-api 58723 SYNC [request@32473 request_id="a8e319ff-3186-469c-aee1-717fa5ec9f7b" duration_ms="1904"] You can't lock the protocol without indexing the solid state XSS circuit!`,
+		Output:      "string",
+		ContentType: "text/plain",
+		Params: []Param{
+			{Field: "format", Display: "Format", Type: "string", Default: "rfc5424", Options: []string{"rfc3164", "rfc5424", "logfmt", "json"}, Description: "Log record format"},
+			{Field: "linecount", Display: "Line Count", Type: "int", Default: "1", Description: "Number of records"},
+			{Field: "rate", Display: "Rate", Type: "float", Default: "10", Description: "Average records per second"},
+			{Field: "hostname", Display: "Hostname", Type: "string", Optional: true, Description: "Host logging the records"},
+			{Field: "appname", Display: "App Name", Type: "string", Optional: true, Description: "App logging the records"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			lo := LogRecordOptions{}
+
+			format, err := info.GetString(m, "format")
+			if err != nil {
+				return nil, err
+			}
+			lo.Format = format
+
+			lineCount, err := info.GetInt(m, "linecount")
+			if err != nil {
+				return nil, err
+			}
+			lo.LineCount = lineCount
+
+			rate, err := info.GetFloat64(m, "rate")
+			if err != nil {
+				return nil, err
+			}
+			lo.Rate = rate
+
+			lo.Hostname, _ = info.GetString(m, "hostname")
+			lo.AppName, _ = info.GetString(m, "appname")
+
+			return logRecordFunc(f, &lo)
+		},
+	})
+}
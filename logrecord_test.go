@@ -0,0 +1,361 @@
+package gofakeit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleLogRecord() {
+	Seed(11)
+
+	value, err := LogRecord(&LogRecordOptions{
+		Format:    "rfc5424",
+		LineCount: 2,
+		Start:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: <182>1 2024-03-01T12:00:00.000000Z api-05 crowd-api 58723 SYNC [request@32473 request_id="a8e319ff-3186-469c-aee1-717fa5ec9f7b" duration_ms="1904"] You can't lock the protocol without indexing the solid state XSS circuit!
+	// <183>1 2024-03-01T12:00:00.191607Z api-05 crowd-api 58723 CACHE [request@32473 request_id="e9b14f0a-88c8-4f62-9da0-09c3e273a085" duration_ms="181"] We need to override the multi-byte ADP feed!
+}
+
+func ExampleFaker_LogRecord() {
+	f := New(11)
+
+	value, err := f.LogRecord(&LogRecordOptions{
+		Format:    "rfc5424",
+		LineCount: 2,
+		Start:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: <182>1 2024-03-01T12:00:00.000000Z api-05 crowd-api 58723 SYNC [request@32473 request_id="a8e319ff-3186-469c-aee1-717fa5ec9f7b" duration_ms="1904"] You can't lock the protocol without indexing the solid state XSS circuit!
+	// <183>1 2024-03-01T12:00:00.191607Z api-05 crowd-api 58723 CACHE [request@32473 request_id="e9b14f0a-88c8-4f62-9da0-09c3e273a085" duration_ms="181"] We need to override the multi-byte ADP feed!
+}
+
+func ExampleWriteSlogRecords() {
+	Seed(11)
+
+	// Any slog.Handler can be load tested, here a json handler that drops the time.
+	// The third record is debug, which the handler is not enabled for
+	h := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	err := WriteSlogRecords(context.Background(), h, &LogRecordOptions{LineCount: 3}, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: {"level":"INFO","msg":"We need to hack the 1080p XSS capacitor!","host":"api-05","app":"crowd-api","pid":58723,"msg_id":"SYNC","request_id":"cfa8e319-ff31-46f6-9cae-e1717fa5ec9f"}
+	// {"level":"INFO","msg":"Navigating the capacitor won't do anything, we need to override the multi-byte ADP feed!","host":"api-05","app":"crowd-api","pid":58723,"msg_id":"CACHE","request_id":"e9b14f0a-88c8-4f62-9da0-09c3e273a085","duration_ms":181}
+}
+
+func TestLogRecordFormats(t *testing.T) {
+	patterns := map[string]*regexp.Regexp{
+		"rfc3164": regexp.MustCompile(`^<\d{1,3}>\w{3} [ \d]\d \d{2}:\d{2}:\d{2} \S+ \S+\[\d+\]: .+$`),
+		"rfc5424": regexp.MustCompile(`^<\d{1,3}>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}Z \S+ \S+ \d+ [A-Z]+ \[request@32473( \w+="([^"\\\]]|\\["\\\]])*")+\] .+$`),
+		"logfmt":  regexp.MustCompile(`^time=\S+ level=(DEBUG|INFO|WARN|ERROR) msg=.+ host=\S+ app=\S+ pid=\d+ msg_id=[A-Z]+ request_id=\S+`),
+	}
+
+	for format, pattern := range patterns {
+		value, err := New(11).LogRecord(&LogRecordOptions{Format: format, LineCount: 200})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(value, "\n")
+		if len(lines) != 200 {
+			t.Errorf("%s: expected 200 lines, got %d", format, len(lines))
+		}
+		for _, line := range lines {
+			if !pattern.MatchString(line) {
+				t.Errorf("%s: unexpected line %s", format, line)
+				break
+			}
+		}
+	}
+
+	// Json lines decode with increasing times from one source
+	value, err := New(11).LogRecord(&LogRecordOptions{Format: "json", LineCount: 200, Hostname: "web-01", AppName: "billing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last time.Time
+	for _, line := range strings.Split(value, "\n") {
+		var entry struct {
+			Time  time.Time `json:"time"`
+			Level string    `json:"level"`
+			Msg   string    `json:"msg"`
+			Host  string    `json:"host"`
+			App   string    `json:"app"`
+			PID   int       `json:"pid"`
+			MsgID string    `json:"msg_id"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry.Host != "web-01" || entry.App != "billing" || entry.PID == 0 || entry.Msg == "" || entry.MsgID == "" {
+			t.Errorf("unexpected json record %s", line)
+		}
+		if !entry.Time.After(last) {
+			t.Errorf("expected time %s to be after %s", entry.Time, last)
+		}
+		last = entry.Time
+	}
+}
+
+func TestLogRecordSeverity(t *testing.T) {
+	value, err := New(11).LogRecord(&LogRecordOptions{Format: "rfc5424", LineCount: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[int]int{}
+	for _, line := range strings.Split(value, "\n") {
+		fields := strings.SplitN(line, " ", 7)
+		pri, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(fields[0], "<"), ">1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Errors come from the error data, everything else from the hacker data
+		severity, msgID := pri%8, fields[5]
+		counts[severity]++
+		isError := msgID == "DB" || msgID == "GRPC" || msgID == "HTTP" || msgID == "RUNTIME" || msgID == "VALIDATION"
+		if (severity <= 3) != isError {
+			t.Errorf("unexpected message id %s for severity %d", msgID, severity)
+		}
+	}
+
+	// Mostly info with some debug, warnings and errors
+	if counts[6] < counts[7] || counts[7] < counts[3] || counts[3] == 0 || counts[4] == 0 {
+		t.Errorf("unexpected severity counts %v", counts)
+	}
+}
+
+func TestLogRecordEscape(t *testing.T) {
+	r := &logRecord{
+		time:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		severity: 3,
+		msgID:    "DB",
+		message:  "failed",
+		attrs:    []slog.Attr{slog.String("msg_id", "DB"), slog.String("user", `a"b\c]d`)},
+	}
+	s := &logRecordSource{hostname: "db-01", appName: "store", pid: 42, facility: 16}
+
+	want := `<131>1 2024-03-01T12:00:00.000000Z db-01 store 42 DB [request@32473 user="a\"b\\c\]d"] failed`
+	if value := r.syslog("rfc5424", s); value != want {
+		t.Errorf("unexpected record\ngot  %s\nwant %s", value, want)
+	}
+
+	want = `<131>Mar  1 12:00:00 db-01 store[42]: failed`
+	if value := r.syslog("rfc3164", s); value != want {
+		t.Errorf("unexpected record\ngot  %s\nwant %s", value, want)
+	}
+}
+
+func TestWriteLogRecords(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	calls := 0
+	err := New(11).WriteLogRecords(context.Background(), &buf, &LogRecordOptions{Format: "logfmt", LineCount: 50, Start: start}, func(written, total int) {
+		calls++
+		if written != calls || total != 50 {
+			t.Errorf("unexpected progress %d of %d", written, total)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 50 || strings.Count(buf.String(), "\n") != 50 {
+		t.Errorf("expected 50 lines and progress calls, got %d and %d", strings.Count(buf.String(), "\n"), calls)
+	}
+
+	// Matches LogRecord with the same seed
+	value, err := New(11).LogRecord(&LogRecordOptions{Format: "logfmt", LineCount: 50, Start: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != value+"\n" {
+		t.Error("expected WriteLogRecords to match LogRecord")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = New(11).WriteLogRecords(ctx, &bytes.Buffer{}, &LogRecordOptions{Format: "json", LineCount: 10}, nil)
+	if err != context.Canceled {
+		t.Errorf("expected context canceled, got %v", err)
+	}
+}
+
+// logRecordTestHandler keeps the records and attributes it is sent
+type logRecordTestHandler struct {
+	level   slog.Level
+	attrs   []slog.Attr
+	records *[]slog.Record
+}
+
+func (h *logRecordTestHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *logRecordTestHandler) Handle(ctx context.Context, r slog.Record) error {
+	*h.records = append(*h.records, r)
+	return nil
+}
+
+func (h *logRecordTestHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logRecordTestHandler{level: h.level, attrs: append(h.attrs, attrs...), records: h.records}
+}
+
+func (h *logRecordTestHandler) WithGroup(name string) slog.Handler { return h }
+
+func TestWriteSlogRecords(t *testing.T) {
+	var records []slog.Record
+	h := &logRecordTestHandler{level: slog.LevelWarn, records: &records}
+
+	calls := 0
+	err := New(11).WriteSlogRecords(context.Background(), h, &LogRecordOptions{LineCount: 1000, AppName: "billing"}, func(written, total int) {
+		calls++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every record is generated but only enabled levels are handled
+	if calls != 1000 || len(records) == 0 || len(records) >= 1000 {
+		t.Errorf("expected 1000 progress calls and some records, got %d and %d", calls, len(records))
+	}
+	for _, r := range records {
+		if r.Level < slog.LevelWarn || r.Message == "" || r.NumAttrs() < 2 {
+			t.Errorf("unexpected record %v %s with %d attrs", r.Level, r.Message, r.NumAttrs())
+		}
+	}
+
+	// The handler given is not changed, the source is added with WithAttrs
+	if len(h.attrs) != 0 {
+		t.Errorf("expected handler to be unchanged, got %v", h.attrs)
+	}
+
+	if err := New(11).WriteSlogRecords(context.Background(), nil, nil, nil); err == nil {
+		t.Error("expected error for nil handler")
+	}
+
+	// Defaults are not written back to the options
+	lo := &LogRecordOptions{}
+	err = New(11).WriteSlogRecords(context.Background(), h, lo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo.Format = "rfc3164"
+	err = New(11).WriteLogRecords(context.Background(), io.Discard, lo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lo.LineCount != 0 || lo.Rate != 0 {
+		t.Errorf("expected options to be left as is, got %+v", lo)
+	}
+}
+
+func TestSlogRecord(t *testing.T) {
+	r := New(11).SlogRecord()
+	if r.Time.IsZero() || r.Message == "" {
+		t.Errorf("unexpected record %v", r)
+	}
+
+	keys := map[string]bool{}
+	r.Attrs(func(a slog.Attr) bool {
+		keys[a.Key] = true
+		return true
+	})
+	for _, key := range []string{"host", "app", "pid", "msg_id", "request_id"} {
+		if !keys[key] {
+			t.Errorf("expected %s attribute, got %v", key, keys)
+		}
+	}
+}
+
+func TestLogRecordErrors(t *testing.T) {
+	if _, err := New(11).LogRecord(&LogRecordOptions{Format: "gelf"}); err == nil {
+		t.Error("expected error for invalid format")
+	}
+	if _, err := New(11).LogRecord(&LogRecordOptions{Format: "json", Rate: -1}); err == nil {
+		t.Error("expected error for negative rate")
+	}
+}
+
+func TestLogRecordNil(t *testing.T) {
+	value, err := New(11).LogRecord(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == "" {
+		t.Error("expected log records")
+	}
+}
+
+func TestLogRecordLookup(t *testing.T) {
+	info := GetFuncLookup("logrecord")
+
+	m := MapParams{
+		"format":    {"rfc3164"},
+		"linecount": {"5"},
+		"hostname":  {"web-01"},
+		"appname":   {"billing"},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(value.(string), "\n")
+	if len(lines) != 5 || !strings.Contains(lines[0], " web-01 billing[") {
+		t.Errorf("unexpected log records %s", value)
+	}
+}
+
+func BenchmarkLogRecord(b *testing.B) {
+	f := New(11)
+	lo := &LogRecordOptions{Format: "rfc5424", LineCount: 100}
+
+	for i := 0; i < b.N; i++ {
+		f.LogRecord(lo)
+	}
+}
+
+func BenchmarkWriteSlogRecords(b *testing.B) {
+	f := New(11)
+	h := slog.NewJSONHandler(io.Discard, nil)
+	lo := &LogRecordOptions{LineCount: 100}
+
+	for i := 0; i < b.N; i++ {
+		f.WriteSlogRecords(context.Background(), h, lo, nil)
+	}
+}
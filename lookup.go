@@ -2,8 +2,11 @@ package gofakeit
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,7 +14,7 @@ import (
 
 // FuncLookups is the primary map array with mapping to all available data
 var FuncLookups map[string]Info
-var lockFuncLookups sync.Mutex
+var lockFuncLookups sync.RWMutex
 
 // MapParams is the values to pass into a lookup generate
 type MapParams map[string]MapParamsValue
@@ -40,13 +43,58 @@ type Param struct {
 	Default     string   `json:"default"`
 	Options     []string `json:"options"`
 	Description string   `json:"description"`
+
+	// Constraints checked by Info.ValidateParams.
+	// Min and Max can be a number or the field name of another param
+	Min      string `json:"min,omitempty"`
+	Max      string `json:"max,omitempty"`
+	Pattern  string `json:"pattern,omitempty"`
+	MinItems int    `json:"min_items,omitempty"`
+	MaxItems int    `json:"max_items,omitempty"`
+}
+
+// ParamError is returned when a param value does not pass validation
+type ParamError struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
 }
 
+func (e *ParamError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("invalid param %s: %s", e.Field, e.Reason)
+	}
+
+	return fmt.Sprintf("invalid param %s value %s: %s", e.Field, e.Value, e.Reason)
+}
+
+// GenerateFunc is the signature used when running a lookup function by name.
+// Middleware added through Use wraps a GenerateFunc with its own logic
+type GenerateFunc func(f *Faker, name string, m *MapParams, info *Info) (any, error)
+
 // Field is used for defining what name and function you to generate for file outuputs
 type Field struct {
 	Name     string    `json:"name"`
 	Function string    `json:"function"`
 	Params   MapParams `json:"params"`
+
+	// Fields will nest child fields as an object instead of calling Function
+	Fields []Field `json:"fields,omitempty"`
+
+	// Ref will nest the fields of a group added with AddFieldGroup
+	Ref string `json:"ref,omitempty"`
+
+	// Count will output an array of values, or a random count between Count and CountMax
+	Count    int `json:"count,omitempty"`
+	CountMax int `json:"count_max,omitempty"`
+
+	// Value will output a fixed value instead of calling Function. Ex: 2.0
+	Value any `json:"value,omitempty"`
+
+	// XML is a comma separated list of flags for xml output, attr, text, cdata or repeat,
+	// and Namespace is declared on the fields element using the prefix of its name if it has one
+	XML       string `json:"xml,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
 }
 
 func init() { initLookup() }
@@ -68,9 +116,15 @@ func initLookup() {
 	addEmojiLookup()
 	addErrorLookup()
 	addFileCSVLookup()
+	addFileDatasetLookup()
 	addFileJSONLookup()
 	addFileLookup()
 	addFileXMLLookup()
+	addFileYAMLLookup()
+	addFileTOMLLookup()
+	addFileTableLookup()
+	addFileMessagePackLookup()
+	addFileCBORLookup()
 	addFinanceLookup()
 	addFoodLookup()
 	addGameLookup()
@@ -80,6 +134,10 @@ func initLookup() {
 	addHtmlLookup()
 	addImageLookup()
 	addInternetLookup()
+	addLogLineLookup()
+	addLogRecordLookup()
+	addHTTPLookup()
+	addHARLookup()
 	addLanguagesLookup()
 	addLoremLookup()
 	addMinecraftLookup()
@@ -191,35 +249,57 @@ func (m *MapParamsValue) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// GetRandomSimpleFunc will return a random function without params, from the fakers
+// functions layered on top of the global FuncLookups
 func GetRandomSimpleFunc(f *Faker) (string, Info) {
+	// Fakers own functions take priority, nil marks a function removed for the faker
+	f.funcLookupsLock.RLock()
+	local := make(map[string]*Info, len(f.funcLookups))
+	for k, info := range f.funcLookups {
+		local[k] = info
+	}
+	f.funcLookupsLock.RUnlock()
+
 	// Loop through all the functions and add them to a slice
-	var keys []string
+	infos := make(map[string]Info)
+	lockFuncLookups.RLock()
 	for k, info := range FuncLookups {
-		// Only grab simple functions
-		if info.Params == nil {
-			keys = append(keys, k)
+		if _, ok := local[k]; !ok && info.Params == nil {
+			infos[k] = info
+		}
+	}
+	lockFuncLookups.RUnlock()
+	for k, info := range local {
+		if info != nil && info.Params == nil {
+			infos[k] = *info
 		}
 	}
 
+	// Sort so the pick follows the seed rather than map order
+	keys := make([]string, 0, len(infos))
+	for k := range infos {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	// Randomly grab a function from the slice
 	randomKey := randomString(f, keys)
 
 	// Return the function name and info
-	return randomKey, FuncLookups[randomKey]
+	return randomKey, infos[randomKey]
 }
 
 // AddFuncLookup takes a field and adds it to map
 func AddFuncLookup(functionName string, info Info) {
-	if FuncLookups == nil {
-		FuncLookups = make(map[string]Info)
-	}
-
 	// Check content type
 	if info.ContentType == "" {
 		info.ContentType = "text/plain"
 	}
 
 	lockFuncLookups.Lock()
+	if FuncLookups == nil {
+		FuncLookups = make(map[string]Info)
+	}
 	FuncLookups[functionName] = info
 	lockFuncLookups.Unlock()
 }
@@ -235,7 +315,9 @@ func GetFuncLookup(functionName string) *Info {
 		return &info
 	}
 
+	lockFuncLookups.RLock()
 	info, ok = FuncLookups[functionName]
+	lockFuncLookups.RUnlock()
 	if ok {
 		return &info
 	}
@@ -245,16 +327,265 @@ func GetFuncLookup(functionName string) *Info {
 
 // RemoveFuncLookup will remove a function from lookup
 func RemoveFuncLookup(functionName string) {
-	_, ok := FuncLookups[functionName]
-	if !ok {
-		return
-	}
-
 	lockFuncLookups.Lock()
 	delete(FuncLookups, functionName)
 	lockFuncLookups.Unlock()
 }
 
+// AddFuncLookup adds a function to the fakers own lookup registry.
+// Functions added here take priority over the global FuncLookups
+// and are only visible to this faker
+func (f *Faker) AddFuncLookup(functionName string, info Info) {
+	// Check content type
+	if info.ContentType == "" {
+		info.ContentType = "text/plain"
+	}
+
+	f.funcLookupsLock.Lock()
+	if f.funcLookups == nil {
+		f.funcLookups = make(map[string]*Info)
+	}
+	f.funcLookups[functionName] = &info
+	f.funcLookupsLock.Unlock()
+}
+
+// GetFuncLookup will lookup a function by checking the fakers own
+// registry first and then falling back to the global FuncLookups
+func (f *Faker) GetFuncLookup(functionName string) *Info {
+	f.funcLookupsLock.RLock()
+	info, ok := f.funcLookups[functionName]
+	f.funcLookupsLock.RUnlock()
+	if ok {
+		// A nil info means it was removed for this faker
+		if info == nil {
+			return nil
+		}
+
+		infoCopy := *info
+		return &infoCopy
+	}
+
+	return GetFuncLookup(functionName)
+}
+
+// hasFuncLookups will return whether the faker has any functions of its own
+func (f *Faker) hasFuncLookups() bool {
+	f.funcLookupsLock.RLock()
+	defer f.funcLookupsLock.RUnlock()
+
+	return len(f.funcLookups) > 0
+}
+
+// RemoveFuncLookup will remove a function for this faker only.
+// The global FuncLookups are left untouched
+func (f *Faker) RemoveFuncLookup(functionName string) {
+	f.funcLookupsLock.Lock()
+	if f.funcLookups == nil {
+		f.funcLookups = make(map[string]*Info)
+	}
+	f.funcLookups[functionName] = nil
+	f.funcLookupsLock.Unlock()
+}
+
+// ResetFuncLookups will clear all functions added or removed
+// on this faker, reverting back to the global FuncLookups
+func (f *Faker) ResetFuncLookups() {
+	f.funcLookupsLock.Lock()
+	f.funcLookups = nil
+	f.funcLookupsLock.Unlock()
+}
+
+// Use adds middleware that wraps every lookup generate call made through
+// Generate, Struct, Template and the file outputs for this faker.
+// Middleware runs in the order it was added, the first being the outermost
+func (f *Faker) Use(middleware ...func(next GenerateFunc) GenerateFunc) {
+	f.funcLookupsLock.Lock()
+	f.middleware = append(f.middleware, middleware...)
+	f.funcLookupsLock.Unlock()
+}
+
+// callFuncLookup will run the info generate function wrapped in any middleware
+func (f *Faker) callFuncLookup(name string, m *MapParams, info *Info) (any, error) {
+	return f.callFuncLookupWith(name, m, info, func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+		return info.Generate(f, m, info)
+	})
+}
+
+// callField will run a field function and return its value along with its output type.
+// Fields that are a generate pattern rather than a function name are run through generate.
+// Ex: {firstname} {lastname} or {active:80|inactive:20}
+func (f *Faker) callField(field *Field) (any, string, error) {
+	if field.Value != nil {
+		return field.Value, fmt.Sprintf("%T", field.Value), nil
+	}
+
+	info := f.GetFuncLookup(field.Function)
+	if info == nil {
+		if !strings.ContainsAny(field.Function, "{[#?") {
+			return nil, "", errors.New("invalid function, " + field.Function + " does not exist")
+		}
+
+		value, err := generate(f, field.Function)
+		if err != nil {
+			return nil, "", err
+		}
+		return value, "string", nil
+	}
+
+	value, err := f.callFuncLookup(field.Function, &field.Params, info)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return value, info.Output, nil
+}
+
+// callFuncLookupWith will validate params and run the final generate function wrapped in any middleware
+func (f *Faker) callFuncLookupWith(name string, m *MapParams, info *Info, final GenerateFunc) (any, error) {
+	f.funcLookupsLock.RLock()
+	middleware := f.middleware
+	f.funcLookupsLock.RUnlock()
+
+	// Validate params right before generating
+	next := func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+		if err := info.ValidateParams(m); err != nil {
+			return nil, err
+		}
+
+		return final(f, name, m, info)
+	}
+
+	// Wrap from the inside out so the first middleware added runs first
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+
+	return next(f, name, m, info)
+}
+
+// paramPatterns caches compiled param patterns
+var paramPatterns sync.Map
+
+// ValidateParams will check the map params against the constraints
+// set on each Param and return a *ParamError for the first failure.
+// Params that are not set in the map are not checked
+func (i *Info) ValidateParams(m *MapParams) error {
+	if m == nil {
+		return nil
+	}
+
+	for _, p := range i.Params {
+		if p.Min == "" && p.Max == "" && p.Pattern == "" && p.MinItems == 0 && p.MaxItems == 0 {
+			continue
+		}
+
+		values, ok := (*m)[p.Field]
+		if !ok {
+			continue
+		}
+
+		// Check item counts
+		if p.MinItems > 0 && len(values) < p.MinItems {
+			return &ParamError{Field: p.Field, Reason: fmt.Sprintf("must have at least %d items", p.MinItems)}
+		}
+		if p.MaxItems > 0 && len(values) > p.MaxItems {
+			return &ParamError{Field: p.Field, Reason: fmt.Sprintf("must have at most %d items", p.MaxItems)}
+		}
+
+		for _, value := range values {
+			if err := i.validateParamValue(m, &p, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *Info) validateParamValue(m *MapParams, p *Param, value string) error {
+	// Check pattern
+	if p.Pattern != "" {
+		re, ok := paramPatterns.Load(p.Pattern)
+		if !ok {
+			compiled, err := regexp.Compile(p.Pattern)
+			if err != nil {
+				return &ParamError{Field: p.Field, Reason: "invalid pattern " + p.Pattern}
+			}
+			re, _ = paramPatterns.LoadOrStore(p.Pattern, compiled)
+		}
+
+		if !re.(*regexp.Regexp).MatchString(value) {
+			return &ParamError{Field: p.Field, Value: value, Reason: "must match pattern " + p.Pattern}
+		}
+	}
+
+	if p.Min == "" && p.Max == "" {
+		return nil
+	}
+
+	// Parse value based on the param type
+	var num float64
+	var err error
+	switch strings.TrimPrefix(p.Type, "[]") {
+	case "int":
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 64)
+		num = float64(n)
+	case "uint":
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 64)
+		num = float64(n)
+	default:
+		num, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil {
+		return &ParamError{Field: p.Field, Value: value, Reason: "must be a valid " + strings.TrimPrefix(p.Type, "[]")}
+	}
+
+	if p.Min != "" {
+		min, name, ok, err := i.paramBound(m, p.Min)
+		if err != nil {
+			return err
+		}
+		if ok && num < min {
+			return &ParamError{Field: p.Field, Value: value, Reason: "must be greater than or equal to " + name}
+		}
+	}
+
+	if p.Max != "" {
+		max, name, ok, err := i.paramBound(m, p.Max)
+		if err != nil {
+			return err
+		}
+		if ok && num > max {
+			return &ParamError{Field: p.Field, Value: value, Reason: "must be less than or equal to " + name}
+		}
+	}
+
+	return nil
+}
+
+// paramBound will return the numeric value of a min or max constraint
+// and how to display it. The bound can reference another param field,
+// if that param has no value ok will be false
+func (i *Info) paramBound(m *MapParams, bound string) (num float64, display string, ok bool, err error) {
+	if num, err := strconv.ParseFloat(bound, 64); err == nil {
+		return num, bound, true, nil
+	}
+
+	p, values, err := i.GetField(m, bound)
+	if err != nil || len(values) == 0 {
+		return 0, "", false, nil
+	}
+
+	num, err = strconv.ParseFloat(values[0], 64)
+	if err != nil {
+		return 0, "", false, &ParamError{Field: p.Field, Value: values[0], Reason: "must be a valid " + p.Type}
+	}
+
+	return num, fmt.Sprintf("%s (%s)", bound, values[0]), true, nil
+}
+
 // GetAny will retrieve Any field from Info
 func (i *Info) GetAny(m *MapParams, field string) (any, error) {
 	_, value, err := i.GetField(m, field)
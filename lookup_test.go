@@ -2,9 +2,11 @@ package gofakeit
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand/v2"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -209,6 +211,12 @@ func TestLookupChecking(t *testing.T) {
 					mapData[p.Field] = []string{fmt.Sprintf("%v", Float32()), fmt.Sprintf("%v", Float32()), fmt.Sprintf("%v", Float32()), fmt.Sprintf("%v", Float32())}
 				case "[]Field":
 					mapData[p.Field] = []string{`{"name":"first_name","function":"firstname"}`}
+				case "[]DatasetTable":
+					mapData[p.Field] = []string{`{"name":"users","count":1,"fields":[{"name":"first_name","function":"firstname"}]}`}
+				case "[]FixedWidthColumn":
+					mapData[p.Field] = []string{`{"width":20}`}
+				case "FixedWidthRecord":
+					mapData[p.Field] = []string{`{"fields":[{"name":"count","function":"recordcount"}],"columns":[{"width":10,"pad":"zero"}]}`}
 				case "interface":
 					mapData[p.Field] = []string{Letter()}
 				case "any":
@@ -355,6 +363,12 @@ func TestLookupCalls(t *testing.T) {
 					mapData.Add(p.Field, fmt.Sprintf("%v", Float32()))
 				case "[]Field":
 					mapData.Add(p.Field, `{"name":"first_name","function":"firstname"}`)
+				case "[]DatasetTable":
+					mapData.Add(p.Field, `{"name":"users","count":1,"fields":[{"name":"first_name","function":"firstname"}]}`)
+				case "[]FixedWidthColumn":
+					mapData.Add(p.Field, `{"width":20}`)
+				case "FixedWidthRecord":
+					mapData.Add(p.Field, `{"fields":[{"name":"count","function":"recordcount"}],"columns":[{"width":10,"pad":"zero"}]}`)
 				case "any":
 					mapData[p.Field] = []string{Letter()}
 				default:
@@ -454,6 +468,12 @@ func TestLookupCallsErrorParams(t *testing.T) {
 					mapData.Add(p.Field, fmt.Sprintf("%v", Float32()))
 				case "[]Field":
 					mapData.Add(p.Field, `{"name":"first_name","function":"firstname"}`)
+				case "[]DatasetTable":
+					mapData.Add(p.Field, `{"name":"users","count":1,"fields":[{"name":"first_name","function":"firstname"}]}`)
+				case "[]FixedWidthColumn":
+					mapData.Add(p.Field, `{"width":20}`)
+				case "FixedWidthRecord":
+					mapData.Add(p.Field, `{"fields":[{"name":"count","function":"recordcount"}],"columns":[{"width":10,"pad":"zero"}]}`)
 				default:
 					t.Fatalf("Looking for %s but switch case doesnt have it", p.Type)
 				}
@@ -471,3 +491,407 @@ func TestLookupCallsErrorParams(t *testing.T) {
 	// Reset lookup functions back
 	initLookup()
 }
+
+func ExampleFaker_AddFuncLookup() {
+	f := New(11)
+
+	// Only this faker will generate example.com emails
+	f.AddFuncLookup("email", Info{
+		Category:    "custom",
+		Description: "Email address on example.com",
+		Example:     "markus@example.com",
+		Output:      "string",
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return strings.ToLower(f.FirstName()) + "@example.com", nil
+		},
+	})
+
+	value, _ := f.Generate("{email}")
+	fmt.Println(value)
+
+	// Output: sonny@example.com
+}
+
+func TestFakerFuncLookup(t *testing.T) {
+	f := New(11)
+
+	f.AddFuncLookup("email", Info{
+		Category: "custom",
+		Output:   "string",
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return "user@example.com", nil
+		},
+	})
+
+	// Global lookup should not be affected
+	if GetFuncLookup("email") == nil {
+		t.Fatal("global email lookup should still exist")
+	}
+	value, err := Generate("{email}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == "user@example.com" {
+		t.Fatal("global generate should not use faker lookup")
+	}
+
+	// Generate
+	value, err = f.Generate("{email}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "user@example.com" {
+		t.Fatalf("expected generate to use faker lookup, got %s", value)
+	}
+
+	// Struct
+	var s struct {
+		Email string `fake:"{email}"`
+	}
+	if err := f.Struct(&s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Email != "user@example.com" {
+		t.Fatalf("expected struct to use faker lookup, got %s", s.Email)
+	}
+
+	// Template
+	value, err = f.Template("{{Email}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "user@example.com" {
+		t.Fatalf("expected template to use faker lookup, got %s", value)
+	}
+
+	// File formats
+	b, err := f.JSON(&JSONOptions{Type: "object", Fields: []Field{{Name: "email", Function: "email"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"email":"user@example.com"}` {
+		t.Fatalf("expected json to use faker lookup, got %s", b)
+	}
+
+	// Remove only for this faker
+	f.RemoveFuncLookup("email")
+	if f.GetFuncLookup("email") != nil {
+		t.Fatal("email should be removed for faker")
+	}
+	if GetFuncLookup("email") == nil {
+		t.Fatal("email should not be removed globally")
+	}
+	if _, err := f.Template("{{Email}}", nil); err == nil {
+		t.Fatal("expected template to fail on removed function")
+	}
+
+	// Reset back to global lookups
+	f.ResetFuncLookups()
+	if f.GetFuncLookup("email") == nil {
+		t.Fatal("email should exist after reset")
+	}
+}
+
+func TestFakerFuncLookupParallel(t *testing.T) {
+	// Group parallel runs so they finish before checking global lookups
+	t.Run("group", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			i := i
+			t.Run(fmt.Sprintf("faker_%d", i), func(t *testing.T) {
+				t.Parallel()
+
+				f := New(0)
+				want := fmt.Sprintf("value%d", i)
+				f.AddFuncLookup("parallelvalue", Info{
+					Category: "custom",
+					Output:   "string",
+					Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+						return want, nil
+					},
+				})
+
+				for ii := 0; ii < 100; ii++ {
+					value, err := f.Generate("{parallelvalue}")
+					if err != nil {
+						t.Fatal(err)
+					}
+					if value != want {
+						t.Fatalf("expected %s got %s", want, value)
+					}
+				}
+			})
+		}
+	})
+
+	if GetFuncLookup("parallelvalue") != nil {
+		t.Fatal("faker lookups should not leak into global lookups")
+	}
+}
+
+func TestGetRandomSimpleFunc(t *testing.T) {
+	// The pick follows the seed
+	first, _ := GetRandomSimpleFunc(New(11))
+	for i := 0; i < 10; i++ {
+		if name, _ := GetRandomSimpleFunc(New(11)); name != first {
+			t.Fatalf("expected %s for the same seed, got %s", first, name)
+		}
+	}
+
+	// Functions removed from the faker are never picked, functions added to it can be
+	f := New(11)
+	f.AddFuncLookup("simplelocal", Info{Output: "string", Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+		return "local", nil
+	}})
+	f.RemoveFuncLookup(first)
+	local := false
+	for i := 0; i < 5000; i++ {
+		name, info := GetRandomSimpleFunc(f)
+		if name == first {
+			t.Fatalf("expected removed function %s to not be picked", first)
+		}
+		if info.Params != nil {
+			t.Fatalf("expected function %s without params", name)
+		}
+		if name == "simplelocal" {
+			local = true
+		}
+	}
+	if !local {
+		t.Error("expected faker function to be picked")
+	}
+
+	// Reading while functions are added globally does not race
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("simpleparallel%d", i)
+			AddFuncLookup(name, Info{Output: "string", Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+				return name, nil
+			}})
+			GetRandomSimpleFunc(New(uint64(i + 1)))
+			RemoveFuncLookup(name)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func ExampleFaker_Use() {
+	f := New(11)
+
+	// Count every generated value by function name
+	counts := map[string]int{}
+	f.Use(func(next GenerateFunc) GenerateFunc {
+		return func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+			counts[name]++
+			return next(f, name, m, info)
+		}
+	})
+
+	f.Generate("{firstname} {lastname} {firstname}")
+	fmt.Println(counts["firstname"], counts["lastname"])
+
+	// Output: 2 1
+}
+
+func TestFakerUse(t *testing.T) {
+	f := New(11)
+
+	// Redact any email
+	var names []string
+	f.Use(func(next GenerateFunc) GenerateFunc {
+		return func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+			names = append(names, name)
+			value, err := next(f, name, m, info)
+			if name == "email" {
+				return "redacted@example.com", err
+			}
+			return value, err
+		}
+	})
+
+	// Generate
+	value, err := f.Generate("{email}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "redacted@example.com" {
+		t.Fatalf("expected generate to be redacted, got %s", value)
+	}
+
+	// Struct
+	var s struct {
+		Email string `fake:"{email}"`
+	}
+	if err := f.Struct(&s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Email != "redacted@example.com" {
+		t.Fatalf("expected struct to be redacted, got %s", s.Email)
+	}
+
+	// Template
+	value, err = f.Template("{{Email}} {{ToUpper Email}} {{$p := Person}}{{$p.FirstName}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(value, "redacted@example.com REDACTED@EXAMPLE.COM ") {
+		t.Fatalf("expected template to be redacted, got %s", value)
+	}
+
+	// Template with params
+	names = nil
+	value, err = f.Template("{{Number 5 5}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "5" || len(names) != 1 || names[0] != "number" {
+		t.Fatalf("expected template number to run through middleware, got %s %v", value, names)
+	}
+
+	// File formats
+	b, err := f.CSV(&CSVOptions{RowCount: 1, Fields: []Field{{Name: "email", Function: "email"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "email\nredacted@example.com\n" {
+		t.Fatalf("expected csv to be redacted, got %s", b)
+	}
+
+	// Global faker should not be affected
+	if Email() == "redacted@example.com" {
+		t.Fatal("middleware should not apply to the global faker")
+	}
+}
+
+func TestFakerUseOrder(t *testing.T) {
+	f := New(11)
+
+	var order []string
+	for _, label := range []string{"first", "second"} {
+		label := label
+		f.Use(func(next GenerateFunc) GenerateFunc {
+			return func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+				order = append(order, label)
+				return next(f, name, m, info)
+			}
+		})
+	}
+
+	// Short circuit with an error
+	f.Use(func(next GenerateFunc) GenerateFunc {
+		return func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+			if name == "number" && m != nil && m.Get("min")[0] == "0" {
+				return nil, fmt.Errorf("min of 0 not allowed")
+			}
+			return next(f, name, m, info)
+		}
+	})
+
+	if _, err := f.Generate("{number:0,10}"); err == nil {
+		t.Fatal("expected middleware error")
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Fatalf("expected middleware to run in order added, got %v", order)
+	}
+}
+
+func TestParamJSON(t *testing.T) {
+	// Constraints are left out of params that dont set them
+	b, err := json.Marshal(Param{Field: "name", Display: "Name", Type: "string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"min", "max", "pattern", "min_items", "max_items"} {
+		if strings.Contains(string(b), `"`+key+`"`) {
+			t.Errorf("expected no %s in %s", key, b)
+		}
+	}
+
+	b, err = json.Marshal(Param{Field: "count", Display: "Count", Type: "int", Min: "1", MaxItems: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"min":"1"`) || !strings.Contains(string(b), `"max_items":3`) {
+		t.Errorf("expected constraints in %s", b)
+	}
+}
+
+func TestInfoValidateParams(t *testing.T) {
+	info := Info{
+		Params: []Param{
+			{Field: "min", Display: "Min", Type: "int", Default: "0", Min: "0"},
+			{Field: "max", Display: "Max", Type: "int", Default: "10", Min: "min", Max: "100"},
+			{Field: "code", Display: "Code", Type: "string", Optional: true, Pattern: "^[A-Z]{3}$"},
+			{Field: "weights", Display: "Weights", Type: "[]float", Optional: true, MinItems: 1, MaxItems: 3, Min: "0"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		params MapParams
+		field  string
+	}{
+		{"valid", MapParams{"min": {"1"}, "max": {"5"}, "code": {"ABC"}, "weights": {"1", "2"}}, ""},
+		{"nil", nil, ""},
+		{"below min", MapParams{"min": {"-1"}}, "min"},
+		{"max below min param", MapParams{"min": {"10"}, "max": {"1"}}, "max"},
+		{"max below default min", MapParams{"max": {"-1"}}, "max"},
+		{"above max", MapParams{"max": {"101"}}, "max"},
+		{"not a number", MapParams{"min": {"abc"}}, "min"},
+		{"pattern", MapParams{"code": {"abc"}}, "code"},
+		{"min items", MapParams{"weights": {}}, "weights"},
+		{"max items", MapParams{"weights": {"1", "2", "3", "4"}}, "weights"},
+		{"item below min", MapParams{"weights": {"1", "-2"}}, "weights"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m *MapParams
+			if tt.params != nil {
+				m = &tt.params
+			}
+
+			err := info.ValidateParams(m)
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var paramErr *ParamError
+			if !errors.As(err, &paramErr) {
+				t.Fatalf("expected ParamError, got %v", err)
+			}
+			if paramErr.Field != tt.field {
+				t.Fatalf("expected error on %s, got %s", tt.field, paramErr.Field)
+			}
+		})
+	}
+}
+
+func TestInfoValidateParamsGenerate(t *testing.T) {
+	_, err := Generate("{number:10,1}")
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || paramErr.Field != "max" {
+		t.Fatalf("expected max param error, got %v", err)
+	}
+
+	_, err = Generate("{sentence:-5}")
+	if !errors.As(err, &paramErr) || paramErr.Field != "wordcount" {
+		t.Fatalf("expected wordcount param error, got %v", err)
+	}
+
+	var s struct {
+		Number int `fake:"{number:10,1}"`
+	}
+	if err := Struct(&s); !errors.As(err, &paramErr) {
+		t.Fatalf("expected struct param error, got %v", err)
+	}
+
+	if _, err := Generate("{number:1,10}"); err != nil {
+		t.Fatal(err)
+	}
+}
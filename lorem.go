@@ -59,7 +59,7 @@ func addLoremLookup() {
 		Example:     "Quia quae repellat consequatur quidem.",
 		Output:      "string",
 		Params: []Param{
-			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Description: "Number of words in a sentence"},
+			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Min: "1", Max: "50", Description: "Number of words in a sentence"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			wordCount, err := info.GetInt(m, "wordcount")
@@ -85,9 +85,9 @@ Aut ea voluptatem dignissimos expedita odit tempore quod aut beatae ipsam iste.
 Explicabo incidunt reprehenderit non quia dignissimos recusandae vitae soluta quia et quia. Aut veniam voluptas consequatur placeat sapiente non eveniet voluptatibus magni velit eum. Nobis vel repellendus sed est qui autem laudantium quidem quam ullam consequatur. Aut iusto ut commodi similique quae voluptatem atque qui fugiat eum aut. Quis distinctio consequatur voluptatem vel aliquid aut laborum facere officiis iure tempora.`,
 		Output: "string",
 		Params: []Param{
-			{Field: "paragraphcount", Display: "Paragraph Count", Type: "int", Default: "2", Description: "Number of paragraphs"},
-			{Field: "sentencecount", Display: "Sentence Count", Type: "int", Default: "2", Description: "Number of sentences in a paragraph"},
-			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Description: "Number of words in a sentence"},
+			{Field: "paragraphcount", Display: "Paragraph Count", Type: "int", Default: "2", Min: "1", Max: "20", Description: "Number of paragraphs"},
+			{Field: "sentencecount", Display: "Sentence Count", Type: "int", Default: "2", Min: "1", Max: "20", Description: "Number of sentences in a paragraph"},
+			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Min: "1", Max: "50", Description: "Number of words in a sentence"},
 			{Field: "paragraphseparator", Display: "Paragraph Separator", Type: "string", Default: "<br />", Description: "String value to add between paragraphs"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
@@ -0,0 +1,320 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MessagePackOptions defines values needed for messagepack generation
+type MessagePackOptions struct {
+	Type     string  `json:"type" xml:"type" fake:"{randomstring:[array,object]}"` // array or object
+	RowCount int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
+	Fields   []Field `json:"fields" xml:"fields" fake:"{fields}"`
+}
+
+// MessagePack generates an object or an array of objects in messagepack format.
+// Objects keep the order of their fields and maps are sorted by key.
+// A nil MessagePackOptions returns a randomly structured MessagePack.
+func MessagePack(mo *MessagePackOptions) ([]byte, error) { return messagePackFunc(GlobalFaker, mo) }
+
+// MessagePack generates an object or an array of objects in messagepack format.
+// Objects keep the order of their fields and maps are sorted by key.
+// A nil MessagePackOptions returns a randomly structured MessagePack.
+func (f *Faker) MessagePack(mo *MessagePackOptions) ([]byte, error) {
+	return messagePackFunc(f, mo)
+}
+
+func messagePackFunc(f *Faker, mo *MessagePackOptions) ([]byte, error) {
+	if mo == nil {
+		// We didn't get a MessagePackOptions, so create a new random one
+		err := f.Struct(&mo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check to make sure they passed in a type
+	if mo.Type != "array" && mo.Type != "object" {
+		return nil, errors.New("invalid type, must be array or object")
+	}
+
+	if mo.Fields == nil || len(mo.Fields) <= 0 {
+		return nil, errors.New("must pass fields in order to build messagepack object(s)")
+	}
+
+	var value any
+	if mo.Type == "object" {
+		// Object only has one row for autoincrement
+		obj, err := f.fieldObject(mo.Fields, 1, 0, binaryLeafValue)
+		if err != nil {
+			return nil, err
+		}
+		value = obj
+	} else {
+		// Make sure you set a row count
+		if mo.RowCount <= 0 {
+			return nil, errors.New("must have row count")
+		}
+
+		rows := make([]any, mo.RowCount)
+		for i := range rows {
+			obj, err := f.fieldObject(mo.Fields, i+1, 0, binaryLeafValue)
+			if err != nil {
+				return nil, err
+			}
+			rows[i] = obj
+		}
+		value = rows
+	}
+
+	var buf bytes.Buffer
+	err := msgpackEncode(&buf, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// msgpackEncode will write a value using the smallest messagepack encoding for its type
+func msgpackEncode(buf *bytes.Buffer, value any) error {
+	// Bytes that are not json are kept as binary
+	if b, ok := value.([]byte); ok {
+		msgpackHeader(buf, len(b), 0, 0, 0xc4, 0xc5, 0xc6)
+		buf.Write(b)
+		return nil
+	}
+
+	value, err := plainValue(value)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+		return nil
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+		return nil
+	case string:
+		msgpackHeader(buf, len(v), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		buf.WriteString(v)
+		return nil
+	case time.Time:
+		msgpackTime(buf, v)
+		return nil
+	case json.Number:
+		number, err := binaryNumber(v)
+		if err != nil {
+			return err
+		}
+		return msgpackEncode(buf, number)
+	case []any:
+		msgpackHeader(buf, len(v), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, item := range v {
+			err := msgpackEncode(buf, item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if keys, values, ok := plainEntries(value); ok {
+		msgpackHeader(buf, len(keys), 0x80, 16, 0, 0xde, 0xdf)
+		for i, key := range keys {
+			err := msgpackEncode(buf, key)
+			if err != nil {
+				return err
+			}
+			err = msgpackEncode(buf, values[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		msgpackInt(buf, rv.Int())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		msgpackUint(buf, rv.Uint())
+		return nil
+	case reflect.Float32:
+		buf.WriteByte(0xca)
+		buf.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(rv.Float()))))
+		return nil
+	case reflect.Float64:
+		buf.WriteByte(0xcb)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(rv.Float())))
+		return nil
+	}
+
+	return fmt.Errorf("messagepack cannot output type %T", value)
+}
+
+// msgpackHeader will write the type and length of a string, binary, array or map.
+// Fixed types hold lengths under fixMax in the type byte, a 0 type skips that size
+func msgpackHeader(buf *bytes.Buffer, n int, fix byte, fixMax int, b8, b16, b32 byte) {
+	switch {
+	case fix != 0 && n < fixMax:
+		buf.WriteByte(fix | byte(n))
+	case b8 != 0 && n <= math.MaxUint8:
+		buf.Write([]byte{b8, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(b16)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buf.WriteByte(b32)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	}
+}
+
+// msgpackInt will write a signed integer, positive values use the unsigned encodings
+func msgpackInt(buf *bytes.Buffer, v int64) {
+	switch {
+	case v >= 0:
+		msgpackUint(buf, uint64(v))
+	case v >= -32:
+		buf.WriteByte(byte(v))
+	case v >= math.MinInt8:
+		buf.Write([]byte{0xd0, byte(v)})
+	case v >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(v)))
+	case v >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(v)))
+	default:
+		buf.WriteByte(0xd3)
+		buf.Write(binary.BigEndian.AppendUint64(nil, uint64(v)))
+	}
+}
+
+// msgpackUint will write an unsigned integer
+func msgpackUint(buf *bytes.Buffer, v uint64) {
+	switch {
+	case v <= 127:
+		buf.WriteByte(byte(v))
+	case v <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(v)})
+	case v <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(v)))
+	case v <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(v)))
+	default:
+		buf.WriteByte(0xcf)
+		buf.Write(binary.BigEndian.AppendUint64(nil, v))
+	}
+}
+
+// msgpackTime will write a time with the timestamp extension type -1 in its 32, 64 or 96 bit format
+func msgpackTime(buf *bytes.Buffer, t time.Time) {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		buf.Write([]byte{0xd6, 0xff})
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(sec)))
+	case sec >= 0 && sec < 1<<34:
+		buf.Write([]byte{0xd7, 0xff})
+		buf.Write(binary.BigEndian.AppendUint64(nil, nsec<<34|uint64(sec)))
+	default:
+		buf.Write([]byte{0xc7, 12, 0xff})
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(nsec)))
+		buf.Write(binary.BigEndian.AppendUint64(nil, uint64(sec)))
+	}
+}
+
+// binaryLeafValue will decode json byte values like plainLeafValue and keep any other bytes as binary
+func binaryLeafValue(value any) (any, error) {
+	if b, ok := value.([]byte); ok && !json.Valid(b) {
+		return b, nil
+	}
+
+	return plainLeafValue(value)
+}
+
+// binaryNumber will convert a json number into an int64, a uint64 if it is too large or a float64
+func binaryNumber(n json.Number) (any, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return v, nil
+		}
+		if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return v, nil
+		}
+	}
+
+	return n.Float64()
+}
+
+func addFileMessagePackLookup() {
+	AddFuncLookup("messagepack", Info{
+		Display:     "MessagePack",
+		Category:    "file",
+		Description: "Compact binary serialization format like json, returns an object or an array of objects",
+		Example:     "[]byte{0x82, 0xaa, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0xa6, 0x4d, 0x61, 0x72, 0x6b, 0x75, 0x73, ...}",
+		Output:      "[]byte",
+		ContentType: "application/x-msgpack",
+		Params: []Param{
+			{Field: "type", Display: "Type", Type: "string", Default: "object", Options: []string{"object", "array"}, Description: "Type of MessagePack, object or array"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of rows in MessagePack array"},
+			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			mo := MessagePackOptions{}
+
+			typ, err := info.GetString(m, "type")
+			if err != nil {
+				return nil, err
+			}
+			mo.Type = typ
+
+			rowcount, err := info.GetInt(m, "rowcount")
+			if err != nil {
+				return nil, err
+			}
+			mo.RowCount = rowcount
+
+			fieldsStr, err := info.GetStringArray(m, "fields")
+			if err != nil {
+				return nil, err
+			}
+
+			// Check to make sure fields has length
+			if len(fieldsStr) > 0 {
+				mo.Fields = make([]Field, len(fieldsStr))
+
+				for i, f := range fieldsStr {
+					// Unmarshal fields string into fields array
+					err = json.Unmarshal([]byte(f), &mo.Fields[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return messagePackFunc(f, &mo)
+		},
+	})
+}
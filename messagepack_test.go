@@ -0,0 +1,205 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func ExampleMessagePack() {
+	Seed(11)
+
+	value, err := MessagePack(&MessagePackOptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "active", Value: true},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%x\n", value)
+
+	// Output: 83a2696401aa66697273745f6e616d65a5536f6e6e79a6616374697665c3
+}
+
+func ExampleFaker_MessagePack() {
+	f := New(11)
+
+	value, err := f.MessagePack(&MessagePackOptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "active", Value: true},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%x\n", value)
+
+	// Output: 83a2696401aa66697273745f6e616d65a5536f6e6e79a6616374697665c3
+}
+
+func TestMessagePackValues(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, "c0"},
+		{false, "c2"},
+		{true, "c3"},
+		{0, "00"},
+		{127, "7f"},
+		{128, "cc80"},
+		{256, "cd0100"},
+		{65536, "ce00010000"},
+		{uint64(1) << 32, "cf0000000100000000"},
+		{uint64(math.MaxUint64), "cfffffffffffffffff"},
+		{-1, "ff"},
+		{-32, "e0"},
+		{-33, "d0df"},
+		{-129, "d1ff7f"},
+		{-32769, "d2ffff7fff"},
+		{int64(math.MinInt64), "d38000000000000000"},
+		{float32(1.5), "ca3fc00000"},
+		{1.5, "cb3ff8000000000000"},
+		{json.Number("300"), "cd012c"},
+		{json.Number("18446744073709551615"), "cfffffffffffffffff"},
+		{json.Number("0.5"), "cb3fe0000000000000"},
+		{"", "a0"},
+		{"a", "a161"},
+		{string(bytes.Repeat([]byte("a"), 32)), "d920" + hex.EncodeToString(bytes.Repeat([]byte("a"), 32))},
+		{[]byte{1, 2}, "c4020102"},
+		{[]any{}, "90"},
+		{[]any{1, "a"}, "9201a161"},
+		{map[string]any{"b": 2, "a": 1}, "82a16101a16202"},
+		{jsonOrderedKeyVal{{Key: "b", Value: 2}, {Key: "a", Value: 1}}, "82a16202a16101"},
+		{time.Unix(1, 0), "d6ff00000001"},
+		{time.Unix(1, 1), "d7ff0000000400000001"},
+		{time.Unix(-1, 0), "c70cff00000000ffffffffffffffff"},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		err := msgpackEncode(&buf, test.value)
+		if err != nil {
+			t.Errorf("%v: %s", test.value, err)
+			continue
+		}
+		if got := hex.EncodeToString(buf.Bytes()); got != test.want {
+			t.Errorf("%#v: expected %s, got %s", test.value, test.want, got)
+		}
+	}
+
+	// Long arrays and maps use 16 bit lengths
+	var buf bytes.Buffer
+	err := msgpackEncode(&buf, make([]any, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte{0xdc, 0x00, 0x10}) {
+		t.Errorf("expected array 16 header, got %x", buf.Bytes()[:3])
+	}
+}
+
+func TestMessagePackArray(t *testing.T) {
+	value, err := New(11).MessagePack(&MessagePackOptions{
+		Type:     "array",
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "tags", Function: "word", Count: 2},
+			{Name: "meta", Function: "json", Params: MapParams{"type": {"object"}, "fields": {`{"name":"n","value":1}`}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Array of 3 maps with 3 keys, starting with id 1
+	if !bytes.HasPrefix(value, []byte{0x93, 0x83, 0xa2, 'i', 'd', 0x01, 0xa4, 't', 'a', 'g', 's', 0x92}) {
+		t.Errorf("unexpected messagepack %x", value)
+	}
+
+	// Json bytes are decoded into a map instead of binary
+	if !bytes.Contains(value, []byte{0xa4, 'm', 'e', 't', 'a', 0x81, 0xa1, 'n', 0x01}) {
+		t.Errorf("expected meta map in %x", value)
+	}
+}
+
+func TestMessagePackErrors(t *testing.T) {
+	fields := []Field{{Name: "id", Function: "autoincrement"}}
+
+	tests := map[string]*MessagePackOptions{
+		"invalid type":  {Type: "list", Fields: fields},
+		"no fields":     {Type: "object"},
+		"no row count":  {Type: "array", Fields: fields},
+		"invalid field": {Type: "object", Fields: []Field{{Name: "x", Function: "notafunction"}}},
+	}
+
+	for name, mo := range tests {
+		_, err := New(11).MessagePack(mo)
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if err := msgpackEncode(&bytes.Buffer{}, make(chan int)); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestMessagePackNil(t *testing.T) {
+	_, err := New(11).MessagePack(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMessagePackLookup(t *testing.T) {
+	info := GetFuncLookup("messagepack")
+
+	m := MapParams{
+		"type":     {"array"},
+		"rowcount": {"2"},
+		"fields":   {`{"name":"id","function":"autoincrement"}`},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0x92, 0x81, 0xa2, 'i', 'd', 0x01, 0x81, 0xa2, 'i', 'd', 0x02}
+	if !bytes.Equal(value.([]byte), want) {
+		t.Errorf("expected %x, got %x", want, value)
+	}
+}
+
+func BenchmarkMessagePack(b *testing.B) {
+	f := New(11)
+	mo := &MessagePackOptions{
+		Type:     "array",
+		RowCount: 10,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "price", Function: "price"},
+			{Name: "created_at", Function: "date"},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		f.MessagePack(mo)
+	}
+}
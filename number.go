@@ -336,7 +336,7 @@ func addNumberLookup() {
 		Output:      "int",
 		Params: []Param{
 			{Field: "min", Display: "Min", Type: "int", Default: "-2147483648", Description: "Minimum integer value"},
-			{Field: "max", Display: "Max", Type: "int", Default: "2147483647", Description: "Maximum integer value"},
+			{Field: "max", Display: "Max", Type: "int", Default: "2147483647", Min: "min", Description: "Maximum integer value"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			min, err := info.GetInt(m, "min")
@@ -435,7 +435,7 @@ func addNumberLookup() {
 		Output:      "uint",
 		Params: []Param{
 			{Field: "min", Display: "Min", Type: "uint", Default: "0", Description: "Minimum uint value"},
-			{Field: "max", Display: "Max", Type: "uint", Default: "4294967295", Description: "Maximum uint value"},
+			{Field: "max", Display: "Max", Type: "uint", Default: "4294967295", Min: "min", Description: "Maximum uint value"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			min, err := info.GetUint(m, "min")
@@ -651,7 +651,7 @@ func addNumberLookup() {
 		Example:     "-1,2,-3,4 => -3",
 		Output:      "int",
 		Params: []Param{
-			{Field: "ints", Display: "Integers", Type: "[]int", Description: "Delimited separated integers"},
+			{Field: "ints", Display: "Integers", Type: "[]int", MinItems: 1, Description: "Delimited separated integers"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			ints, err := info.GetIntArray(m, "ints")
@@ -670,7 +670,7 @@ func addNumberLookup() {
 		Example:     "1,2,3,4 => 4",
 		Output:      "uint",
 		Params: []Param{
-			{Field: "uints", Display: "Unsigned Integers", Type: "[]uint", Description: "Delimited separated unsigned integers"},
+			{Field: "uints", Display: "Unsigned Integers", Type: "[]uint", MinItems: 1, Description: "Delimited separated unsigned integers"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			uints, err := info.GetUintArray(m, "uints")
@@ -0,0 +1,613 @@
+package gofakeit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// patternEscapes are the characters that can be escaped with a backslash
+// within a generate pattern. Ex: \# outputs a literal #
+const patternEscapes = `#?{}[]|\`
+
+type patternKind int
+
+const (
+	patternLiteral patternKind = iota
+	patternDigit
+	patternLetter
+	patternGroup
+	patternOptional
+)
+
+// patternNode is a single parsed piece of a generate pattern
+type patternNode struct {
+	kind    patternKind
+	literal string
+
+	// Group contents split on top level |. Ex: {firstname|lower}
+	segments [][]*patternNode
+
+	// Group segment text with any random parts left out, used to tell
+	// function groups from inline alternatives without running them
+	shapes []string
+
+	// Alternative values and weights for each segment. Ex: {active:80|deleted:20}
+	alternatives [][]*patternNode
+	weights      []float32
+	weighted     bool
+
+	// Chance out of 100 an optional segment is included. Ex: [ {middlename}]?50%
+	percent float64
+
+	// Precompiled function call for groups without any random parts
+	call *patternCall
+}
+
+// patternCall is a function lookup resolved ahead of time
+type patternCall struct {
+	name       string
+	info       *Info
+	params     *MapParams
+	transforms []string
+}
+
+// pattern is a generate pattern parsed into nodes
+type pattern struct {
+	source  string
+	nodes   []*patternNode
+	digits  int
+	letters int
+}
+
+// parsePattern will tokenize a generate pattern in a single pass
+func parsePattern(str string) *pattern {
+	pp := &patternParser{str: str}
+	segments, _ := pp.parseSegments(0)
+
+	return &pattern{source: str, nodes: segments[0], digits: pp.digits, letters: pp.letters}
+}
+
+// CompiledPattern is a generate pattern that has been parsed ahead of time.
+// It is safe for concurrent use and can be reused with any faker
+type CompiledPattern struct {
+	pattern *pattern
+}
+
+// CompilePattern will parse a generate pattern and resolve its functions ahead of time.
+// Use it in place of Generate when the same pattern is run many times
+func CompilePattern(str string) (*CompiledPattern, error) {
+	p := parsePattern(str)
+	if err := p.compile(p.nodes); err != nil {
+		return nil, err
+	}
+
+	return &CompiledPattern{pattern: p}, nil
+}
+
+// Generate will run the pattern with the given faker
+func (cp *CompiledPattern) Generate(f *Faker) (string, error) { return cp.pattern.generate(f) }
+
+// Next will run the pattern with the given faker.
+// Any function error results in an empty string, use Generate to get the error
+func (cp *CompiledPattern) Next(f *Faker) string {
+	value, err := cp.pattern.generate(f)
+	if err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// String returns the source text used to compile the pattern
+func (cp *CompiledPattern) String() string { return cp.pattern.source }
+
+type patternParser struct {
+	str     string
+	pos     int
+	digits  int
+	letters int
+}
+
+// parseLeadingGroup will check if {{ opens a list of alternatives whose
+// first choice is a nested group and if so add it to nodes.
+// Otherwise the parser is left untouched so {{ stays a literal bracket
+func (pp *patternParser) parseLeadingGroup(nodes *[]*patternNode, flush func()) bool {
+	start, digits, letters := pp.pos, pp.digits, pp.letters
+
+	pp.pos++
+	segments, closed := pp.parseSegments('}')
+	if closed && len(segments) > 1 {
+		flush()
+		*nodes = append(*nodes, newPatternGroup(segments))
+		return true
+	}
+
+	pp.pos, pp.digits, pp.letters = start, digits, letters
+	return false
+}
+
+// parseSegments will parse nodes until the end of the string or the closer.
+// Closer is } within a group, where top level | start a new segment,
+// and ] within an optional segment
+func (pp *patternParser) parseSegments(closer byte) ([][]*patternNode, bool) {
+	var segments [][]*patternNode
+	var nodes []*patternNode
+	var lit strings.Builder
+	inGroup := closer == '}'
+	depth := 0
+
+	flush := func() {
+		if lit.Len() > 0 {
+			nodes = append(nodes, &patternNode{kind: patternLiteral, literal: lit.String()})
+			lit.Reset()
+		}
+	}
+
+	for pp.pos < len(pp.str) {
+		c := pp.str[pp.pos]
+		var next byte
+		if pp.pos+1 < len(pp.str) {
+			next = pp.str[pp.pos+1]
+		}
+
+		switch {
+		case c == '\\' && next != 0 && strings.IndexByte(patternEscapes, next) != -1:
+			lit.WriteByte(next)
+			pp.pos += 2
+		case !inGroup && c == '{' && next == c && pp.parseLeadingGroup(&nodes, flush):
+			// Alternatives starting with a nested group. Ex: {{email}|{phone}}
+		case !inGroup && (c == '{' || c == '}') && next == c:
+			// Doubled brackets outside of a group are literals. Ex: {{ or }}
+			lit.WriteByte(c)
+			pp.pos += 2
+		case c == hashtag:
+			flush()
+			nodes = append(nodes, &patternNode{kind: patternDigit})
+			pp.digits++
+			pp.pos++
+		case c == questionmark:
+			flush()
+			nodes = append(nodes, &patternNode{kind: patternLetter})
+			pp.letters++
+			pp.pos++
+		case c == '{':
+			flush()
+			pp.pos++
+			groupSegments, closed := pp.parseSegments('}')
+			if closed {
+				nodes = append(nodes, newPatternGroup(groupSegments))
+				continue
+			}
+
+			// Unclosed group, keep its contents as is
+			nodes = append(nodes, &patternNode{kind: patternLiteral, literal: "{"})
+			for i, segment := range groupSegments {
+				if i > 0 {
+					nodes = append(nodes, &patternNode{kind: patternLiteral, literal: "|"})
+				}
+				nodes = append(nodes, segment...)
+			}
+		case !inGroup && c == '[':
+			flush()
+			pp.pos++
+			inner, closed := pp.parseSegments(']')
+			if closed && pp.pos < len(pp.str) && pp.str[pp.pos] == questionmark {
+				pp.pos++
+				nodes = append(nodes, &patternNode{kind: patternOptional, segments: inner, percent: pp.parsePercent()})
+				continue
+			}
+
+			// Not an optional segment, keep the brackets as is
+			nodes = append(nodes, &patternNode{kind: patternLiteral, literal: "["})
+			nodes = append(nodes, inner[0]...)
+			if closed {
+				nodes = append(nodes, &patternNode{kind: patternLiteral, literal: "]"})
+			}
+		case closer != 0 && c == closer:
+			flush()
+			pp.pos++
+			return append(segments, nodes), true
+		case inGroup && c == '|' && depth == 0:
+			flush()
+			segments = append(segments, nodes)
+			nodes = nil
+			pp.pos++
+		default:
+			// Track brackets so | within params stay put. Ex: {regex:(a|b)}
+			if inGroup {
+				switch c {
+				case '[', '(':
+					depth++
+				case ']', ')':
+					if depth > 0 {
+						depth--
+					}
+				}
+			}
+			lit.WriteByte(c)
+			pp.pos++
+		}
+	}
+
+	flush()
+	return append(segments, nodes), false
+}
+
+// parsePercent will parse the chance after an optional segment. Ex: ?30%
+// Without one the segment is included half the time
+func (pp *patternParser) parsePercent() float64 {
+	end := pp.pos
+	for end < len(pp.str) && (pp.str[end] >= '0' && pp.str[end] <= '9' || pp.str[end] == '.') {
+		end++
+	}
+	if end == pp.pos || end >= len(pp.str) || pp.str[end] != '%' {
+		return 50
+	}
+
+	percent, err := strconv.ParseFloat(pp.str[pp.pos:end], 64)
+	if err != nil {
+		return 50
+	}
+	pp.pos = end + 1
+
+	return percent
+}
+
+// newPatternGroup will create a group node, working out the shape and
+// alternative weights of each segment ahead of time
+func newPatternGroup(segments [][]*patternNode) *patternNode {
+	node := &patternNode{kind: patternGroup, segments: segments}
+	if len(segments) == 1 {
+		return node
+	}
+
+	node.shapes = make([]string, len(segments))
+	node.alternatives = make([][]*patternNode, len(segments))
+	node.weights = make([]float32, len(segments))
+	for i, segment := range segments {
+		for _, n := range segment {
+			if n.kind == patternLiteral {
+				node.shapes[i] += n.literal
+			} else {
+				node.shapes[i] += "\x00"
+			}
+		}
+
+		// Check for a trailing weight. Ex: active:80
+		node.alternatives[i] = segment
+		node.weights[i] = 1
+		if len(segment) == 0 || segment[len(segment)-1].kind != patternLiteral {
+			continue
+		}
+		last := segment[len(segment)-1].literal
+		index := strings.LastIndexByte(last, ':')
+		if index == -1 {
+			continue
+		}
+		weight, err := strconv.ParseFloat(last[index+1:], 32)
+		if err != nil || weight < 0 {
+			continue
+		}
+
+		node.weighted = true
+		node.weights[i] = float32(weight)
+		node.alternatives[i] = append([]*patternNode{}, segment[:len(segment)-1]...)
+		if index > 0 {
+			node.alternatives[i] = append(node.alternatives[i], &patternNode{kind: patternLiteral, literal: last[:index]})
+		}
+	}
+
+	return node
+}
+
+// compile will resolve the function lookup and params of every group
+// that has no random parts, so they dont have to be parsed on each run
+func (p *pattern) compile(nodes []*patternNode) error {
+	for _, node := range nodes {
+		if node.kind == patternOptional {
+			if err := p.compile(node.segments[0]); err != nil {
+				return err
+			}
+			continue
+		}
+		if node.kind != patternGroup {
+			continue
+		}
+		if err := checkPatternTransforms(node, GetFuncLookup); err != nil {
+			return err
+		}
+
+		static := true
+		segments := make([]string, len(node.segments))
+		for i, segment := range node.segments {
+			if err := p.compile(segment); err != nil {
+				return err
+			}
+
+			for _, n := range segment {
+				if n.kind != patternLiteral {
+					static = false
+				}
+				segments[i] += n.literal
+			}
+		}
+		if !static {
+			continue
+		}
+
+		fName, fParams, fTransforms := splitPatternGroup(segments)
+		info := GetFuncLookup(fName)
+		if info == nil {
+			continue
+		}
+
+		// Functions without params cant take a |, leave them as alternatives
+		if len(segments)-len(fTransforms) > 1 && len(info.Params) == 0 {
+			continue
+		}
+
+		mapParams, err := parseMapParams(info, fParams)
+		if err != nil {
+			return err
+		}
+
+		node.call = &patternCall{name: fName, info: info, params: mapParams, transforms: fTransforms}
+	}
+
+	return nil
+}
+
+// splitPatternGroup will split group segments into the function name, params and transforms.
+// Trailing segments are transforms as long as they are known transforms,
+// otherwise they belong to the function params. Ex: {regex:a|b}
+func splitPatternGroup(segments []string) (string, string, []string) {
+	split := transformSplit(segments)
+
+	// Check if has params separated by :
+	fName, fParams, _ := strings.Cut(strings.Join(segments[:split], "|"), ":")
+
+	return fName, fParams, segments[split:]
+}
+
+// transformSplit will return the index of the first trailing transform segment
+func transformSplit(segments []string) int {
+	split := len(segments)
+	for split > 1 {
+		name, _, _ := strings.Cut(segments[split-1], ":")
+		if GetTransform(name) == nil {
+			break
+		}
+		split--
+	}
+
+	return split
+}
+
+// checkPatternTransforms will return an error for a group that starts with a function name
+// but has segments after it that are not known transforms. Function names are not resolved
+// inside alternatives, so these are typos or functions that need their own braces. Ex: {firstname|lowr}
+func checkPatternTransforms(node *patternNode, lookup func(string) *Info) error {
+	if len(node.segments) < 2 || node.weighted || strings.ContainsAny(node.shapes[0], ":\x00") || lookup(node.shapes[0]) == nil {
+		return nil
+	}
+
+	for _, shape := range node.shapes[1:] {
+		name, _, _ := strings.Cut(shape, ":")
+		if !strings.Contains(name, "\x00") && GetTransform(name) == nil {
+			return fmt.Errorf("unknown transform %q", name)
+		}
+	}
+
+	return nil
+}
+
+// patternState holds the values for a single run of a pattern
+type patternState struct {
+	f       *Faker
+	digits  []byte
+	letters []byte
+	zero    byte
+
+	// Whether the faker has its own function lookups that
+	// take priority over any precompiled calls
+	local bool
+}
+
+// generate will run the pattern.
+// All # are filled in first, then all ? and lastly functions, innermost first
+func (p *pattern) generate(f *Faker) (string, error) {
+	ps := &patternState{f: f, local: f.hasFuncLookups()}
+
+	if p.digits > 0 {
+		ps.digits = make([]byte, p.digits)
+		for i := range ps.digits {
+			ps.digits[i] = byte(randDigit(f))
+		}
+	}
+
+	// Make sure the pattern doesnt start with a 0
+	if len(p.nodes) > 0 {
+		switch first := p.nodes[0]; first.kind {
+		case patternDigit:
+			if ps.digits[0] == '0' {
+				ps.digits[0] = byte(f.IntN(8)+1) + '0'
+			}
+		case patternLiteral:
+			if first.literal[0] == '0' {
+				ps.zero = byte(f.IntN(8)+1) + '0'
+			}
+		}
+	}
+
+	if p.letters > 0 {
+		ps.letters = make([]byte, p.letters)
+		for i := range ps.letters {
+			ps.letters[i] = byte(randLetter(f))
+		}
+	}
+
+	var sb strings.Builder
+	if err := ps.render(&sb, p.nodes); err != nil {
+		return "", err
+	}
+
+	value := sb.String()
+	if ps.zero != 0 {
+		value = string(ps.zero) + value[1:]
+	}
+
+	return value, nil
+}
+
+// render will write the nodes to the string builder
+func (ps *patternState) render(sb *strings.Builder, nodes []*patternNode) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case patternLiteral:
+			sb.WriteString(node.literal)
+		case patternDigit:
+			sb.WriteByte(ps.digits[0])
+			ps.digits = ps.digits[1:]
+		case patternLetter:
+			sb.WriteByte(ps.letters[0])
+			ps.letters = ps.letters[1:]
+		case patternGroup:
+			value, err := ps.renderGroup(node)
+			if err != nil {
+				return err
+			}
+			sb.WriteString(value)
+		case patternOptional:
+			if ps.f.Float64()*100 >= node.percent {
+				continue
+			}
+			if err := ps.render(sb, node.segments[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderGroup will run the function within a group, pick one of its inline
+// alternatives or return it as is if it is not a known function
+func (ps *patternState) renderGroup(node *patternNode) (string, error) {
+	if node.call != nil && !ps.local {
+		return ps.call(node.call.name, node.call.info, node.call.params, node.call.transforms)
+	}
+	if len(node.segments) > 1 {
+		if err := checkPatternTransforms(node, ps.f.GetFuncLookup); err != nil {
+			return "", err
+		}
+		if !ps.isFuncGroup(node) {
+			return ps.renderAlternatives(node)
+		}
+	}
+
+	segments := make([]string, len(node.segments))
+	for i, nodes := range node.segments {
+		var sb strings.Builder
+		if err := ps.render(&sb, nodes); err != nil {
+			return "", err
+		}
+		segments[i] = sb.String()
+	}
+
+	fName, fParams, fTransforms := splitPatternGroup(segments)
+
+	// Check to see if its a replaceable lookup function
+	info := ps.f.GetFuncLookup(fName)
+	if info == nil {
+		return "{" + strings.Join(segments, "|") + "}", nil
+	}
+
+	mapParams, err := parseMapParams(info, fParams)
+	if err != nil {
+		return "", err
+	}
+
+	return ps.call(fName, info, mapParams, fTransforms)
+}
+
+// isFuncGroup will check if a group with multiple segments is a function call.
+// A function without params cant take a | so its segments are alternatives. Ex: {email:80|phone:20}
+func (ps *patternState) isFuncGroup(node *patternNode) bool {
+	split := transformSplit(node.shapes)
+	fName, _, _ := strings.Cut(strings.Join(node.shapes[:split], "|"), ":")
+
+	info := ps.f.GetFuncLookup(fName)
+	if info == nil {
+		return false
+	}
+
+	return split == 1 || len(info.Params) > 0
+}
+
+// renderAlternatives will pick one of the group segments, by weight if any are given,
+// and pass it through any trailing transforms. Ex: {a|b|c|upper}
+func (ps *patternState) renderAlternatives(node *patternNode) (string, error) {
+	split := transformSplit(node.shapes)
+
+	// Pick an alternative
+	index := 0
+	if node.weighted {
+		options := make([]any, split)
+		for i := range options {
+			options[i] = i
+		}
+		picked, err := weighted(ps.f, options, node.weights[:split])
+		if err != nil {
+			return "", err
+		}
+		index = picked.(int)
+	} else {
+		index = ps.f.IntN(split)
+	}
+
+	var sb strings.Builder
+	if err := ps.render(&sb, node.alternatives[index]); err != nil {
+		return "", err
+	}
+	value := sb.String()
+
+	// Run value through any transforms
+	if split < len(node.segments) {
+		transforms := make([]string, 0, len(node.segments)-split)
+		for _, nodes := range node.segments[split:] {
+			var tb strings.Builder
+			if err := ps.render(&tb, nodes); err != nil {
+				return "", err
+			}
+			transforms = append(transforms, tb.String())
+		}
+
+		var err error
+		value, err = applyTransforms(ps.f, value, transforms)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return value, nil
+}
+
+// call will run the function and pass its value through any transforms
+func (ps *patternState) call(name string, info *Info, m *MapParams, transforms []string) (string, error) {
+	fValue, err := ps.f.callFuncLookup(name, m, info)
+	if err != nil {
+		return "", err
+	}
+
+	// Run value through any transforms
+	value := fmt.Sprintf("%v", fValue)
+	if len(transforms) > 0 {
+		value, err = applyTransforms(ps.f, value, transforms)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return value, nil
+}
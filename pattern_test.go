@@ -0,0 +1,155 @@
+package gofakeit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func ExampleCompilePattern() {
+	email, err := CompilePattern("{firstname|lower}.{lastname|lower}@{domainname}")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	f := New(11)
+	fmt.Println(email.Next(f))
+
+	// Output: sonny.stiedemann@directmaximize.name
+}
+
+func TestCompilePattern(t *testing.T) {
+	patterns := []string{
+		"{firstname} {lastname} {email} #?#?#?",
+		"{randomstring:[{firstname},{lastname}]}",
+		"{number:1,100|pad:5}-{lexify:???}",
+		"0## {regex:[a-c]{5}} {unknown}",
+		`\#{{literal}} {sentence:3|upper}`,
+	}
+
+	for _, pattern := range patterns {
+		cp, err := CompilePattern(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cp.String() != pattern {
+			t.Errorf("expected source %s, got %s", pattern, cp.String())
+		}
+
+		// Should match the output of Generate with the same seed
+		f1 := New(11)
+		f2 := New(11)
+		for i := 0; i < 10; i++ {
+			want, err := f2.Generate(pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := cp.Generate(f1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Fatalf("%s: expected %s, got %s", pattern, want, got)
+			}
+		}
+	}
+}
+
+func TestCompilePatternError(t *testing.T) {
+	if _, err := CompilePattern("{randomstring:[a,b}"); err == nil {
+		t.Error("expected error for invalid params")
+	}
+	if _, err := CompilePattern("{firstname|lowr}"); err == nil {
+		t.Error("expected error for unknown transform")
+	}
+
+	cp, err := CompilePattern("{number:10,1}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cp.Generate(New(11)); err == nil {
+		t.Error("expected error for invalid number range")
+	}
+	if value := cp.Next(New(11)); value != "" {
+		t.Errorf("expected empty value on error, got %s", value)
+	}
+}
+
+func TestCompilePatternFakerLookup(t *testing.T) {
+	cp, err := CompilePattern("{firstname}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := New(11)
+	f.AddFuncLookup("firstname", Info{
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return "Local", nil
+		},
+	})
+
+	if value := cp.Next(f); value != "Local" {
+		t.Errorf("expected faker function to be used, got %s", value)
+	}
+	if value := cp.Next(New(11)); value == "Local" {
+		t.Error("expected global function to be used")
+	}
+
+	// Transforms after faker functions are checked when run
+	cp, err = CompilePattern("{localname|lowr}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.AddFuncLookup("localname", Info{
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return "Local", nil
+		},
+	})
+	if _, err := cp.Generate(f); err == nil {
+		t.Error("expected error for unknown transform")
+	}
+}
+
+func TestCompilePatternConcurrent(t *testing.T) {
+	cp, err := CompilePattern("{firstname|lower}.{lastname|lower}@{domainname} ###")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(seed uint64) {
+			defer wg.Done()
+
+			f := New(seed)
+			for ii := 0; ii < 100; ii++ {
+				if value := cp.Next(f); strings.ContainsAny(value, "{}#") {
+					t.Errorf("unexpected value %s", value)
+				}
+			}
+		}(uint64(i + 1))
+	}
+	wg.Wait()
+}
+
+func BenchmarkCompilePattern(b *testing.B) {
+	pattern := "{firstname}.{lastname}@{domainname}"
+
+	b.Run("Generate", func(b *testing.B) {
+		f := New(11)
+		for i := 0; i < b.N; i++ {
+			f.Generate(pattern)
+		}
+	})
+
+	b.Run("Compiled", func(b *testing.B) {
+		f := New(11)
+		cp, _ := CompilePattern(pattern)
+		for i := 0; i < b.N; i++ {
+			cp.Next(f)
+		}
+	})
+}
@@ -286,7 +286,7 @@ func addPaymentLookup() {
 		Output:      "float64",
 		Params: []Param{
 			{Field: "min", Display: "Min", Type: "float", Default: "0", Description: "Minimum price value"},
-			{Field: "max", Display: "Max", Type: "float", Default: "1000", Description: "Maximum price value"},
+			{Field: "max", Display: "Max", Type: "float", Default: "1000", Min: "min", Description: "Maximum price value"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			min, err := info.GetFloat64(m, "min")
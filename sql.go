@@ -1,91 +1,212 @@
 package gofakeit
 
 import (
+	"bufio"
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type SQLOptions struct {
 	Table  string  `json:"table" xml:"table"`   // Table name we are inserting into
 	Count  int     `json:"count" xml:"count"`   // How many entries (tuples) we're generating
 	Fields []Field `json:"fields" xml:"fields"` // The fields to be generated
+
+	Dialect         string   `json:"dialect" xml:"dialect"`                   // postgres, mysql, sqlite or sqlserver, empty leaves names and values as is
+	BatchSize       int      `json:"batch_size" xml:"batch_size"`             // Rows per insert statement, 0 for all rows in one statement
+	OnConflict      string   `json:"on_conflict" xml:"on_conflict"`           // ignore or update rows that already exist, requires a dialect
+	ConflictColumns []string `json:"conflict_columns" xml:"conflict_columns"` // Unique columns checked on conflict, defaults to the first field
+
+	CreateTable bool     `json:"create_table" xml:"create_table"` // Output a create table statement before the inserts
+	Nullable    []string `json:"nullable" xml:"nullable"`         // Columns created without NOT NULL
 }
 
+// sqlServerMaxRows is the most rows sql server allows in a single insert statement
+const sqlServerMaxRows = 1000
+
 func SQL(so *SQLOptions) (string, error) { return sqlFunc(GlobalFaker, so) }
 
 func (f *Faker) SQL(so *SQLOptions) (string, error) { return sqlFunc(f, so) }
 
 func sqlFunc(f *Faker, so *SQLOptions) (string, error) {
+	var sb strings.Builder
+	err := writeSQL(context.Background(), f, &sb, so, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// WriteSQL streams an insert statement to w as values are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each value.
+func WriteSQL(ctx context.Context, w io.Writer, so *SQLOptions, progress WriteProgress) error {
+	return writeSQL(ctx, GlobalFaker, w, so, progress)
+}
+
+// WriteSQL streams an insert statement to w as values are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each value.
+func (f *Faker) WriteSQL(ctx context.Context, w io.Writer, so *SQLOptions, progress WriteProgress) error {
+	return writeSQL(ctx, f, w, so, progress)
+}
+
+func writeSQL(ctx context.Context, f *Faker, w io.Writer, so *SQLOptions, progress WriteProgress) error {
+	if so == nil {
+		return errors.New("must pass options in order to generate SQL")
+	}
 	if so.Table == "" {
-		return "", errors.New("must provide table name to generate SQL")
+		return errors.New("must provide table name to generate SQL")
 	}
 	if so.Fields == nil || len(so.Fields) <= 0 {
-		return "", errors.New(("must pass fields in order to generate SQL queries"))
+		return errors.New(("must pass fields in order to generate SQL queries"))
 	}
 	if so.Count <= 0 {
-		return "", errors.New("must have entry count")
+		return errors.New("must have entry count")
 	}
 
-	var sb strings.Builder
-	sb.WriteString("INSERT INTO " + so.Table + " ")
+	dialect, err := sqlDialect(so)
+	if err != nil {
+		return err
+	}
+
+	var create string
+	if so.CreateTable {
+		outputs := make([]string, len(so.Fields))
+		for i := range so.Fields {
+			outputs[i] = sqlFieldOutput(f, &so.Fields[i])
+		}
+		create = sqlCreateTable(dialect, so, outputs, nil)
+	}
+
+	return sqlWriteRows(ctx, w, dialect, so, create, func(row int, col int) (any, string, error) {
+		// If autoincrement, add based upon loop
+		if so.Fields[col].Function == "autoincrement" {
+			return row + 1, "int", nil
+		}
+
+		return f.callField(&so.Fields[col])
+	}, progress)
+}
+
+// sqlDialect will return the lower cased dialect or an error if it is not supported
+func sqlDialect(so *SQLOptions) (string, error) {
+	dialect := strings.ToLower(so.Dialect)
+	switch dialect {
+	case "", "postgres", "mysql", "sqlite", "sqlserver":
+		return dialect, nil
+	default:
+		return "", errors.New("invalid dialect, must be postgres, mysql, sqlite or sqlserver")
+	}
+}
+
+// sqlWriteRows will write the create statement, if not empty, followed by insert statements
+// for so.Count rows with each value and its output type returned by cell
+func sqlWriteRows(ctx context.Context, w io.Writer, dialect string, so *SQLOptions, create string, cell func(row int, col int) (any, string, error), progress WriteProgress) error {
+	conflict, err := sqlConflict(dialect, so)
+	if err != nil {
+		return err
+	}
+
+	// Rows per statement
+	batch := so.BatchSize
+	if batch <= 0 || batch > so.Count {
+		batch = so.Count
+	}
+	if dialect == "sqlserver" && batch > sqlServerMaxRows {
+		batch = sqlServerMaxRows
+	}
 
 	// Loop through each field and put together column names
 	var cols []string
 	for _, f := range so.Fields {
-		cols = append(cols, f.Name)
+		cols = append(cols, sqlIdent(dialect, f.Name))
+	}
+
+	insert := "INSERT INTO "
+	if dialect == "mysql" && strings.ToLower(so.OnConflict) == "ignore" {
+		insert = "INSERT IGNORE INTO "
+	}
+	insert += sqlIdent(dialect, so.Table) + " (" + strings.Join(cols, ", ") + ") VALUES "
+
+	sb := bufio.NewWriter(w)
+	if create != "" {
+		sb.WriteString(create)
+		if so.Count > 0 {
+			sb.WriteString("\n")
+		}
 	}
-	sb.WriteString("(" + strings.Join(cols, ", ") + ")")
 
-	sb.WriteString(" VALUES ")
 	for i := 0; i < so.Count; i++ {
-		// Start opening value
-		sb.WriteString("(")
+		if err := ctx.Err(); err != nil {
+			sb.Flush()
+			return err
+		}
 
-		// Now, we need to add all of our fields
-		var endStr string
-		for ii, field := range so.Fields {
-			// Set end of value string
-			endStr = ", "
-			if ii == len(so.Fields)-1 {
-				endStr = ""
+		// Start a new statement for each batch
+		if i%batch == 0 {
+			if i > 0 {
+				sb.WriteString("\n")
 			}
+			sb.WriteString(insert)
+		} else {
+			sb.WriteString(",")
+		}
 
-			// If autoincrement, add based upon loop
-			if field.Function == "autoincrement" {
-				sb.WriteString(fmt.Sprintf("%d%s", i+1, endStr))
-				continue
-			}
+		// Start opening value
+		sb.WriteString("(")
 
-			// Get the function info for the field
-			funcInfo := GetFuncLookup(field.Function)
-			if funcInfo == nil {
-				return "", errors.New("invalid function, " + field.Function + " does not exist")
+		// Now, we need to add all of our fields
+		for ii := range so.Fields {
+			if ii > 0 {
+				sb.WriteString(", ")
 			}
 
 			// Generate the value
-			val, err := funcInfo.Generate(f, &field.Params, funcInfo)
+			val, output, err := cell(i, ii)
 			if err != nil {
-				return "", err
+				return err
 			}
 
 			// Convert the output value to the proper SQL type
-			convertType := sqlConvertType(funcInfo.Output, val)
+			var convertType string
+			if dialect == "" {
+				convertType = sqlConvertType(output, val)
+			} else {
+				convertType, err = sqlLiteral(dialect, val)
+				if err != nil {
+					return err
+				}
+			}
 
-			// If its the last field, we need to close the value
-			sb.WriteString(convertType + endStr)
+			sb.WriteString(convertType)
 		}
 
-		// If its the last value, we need to close the value
-		if i == so.Count-1 {
-			sb.WriteString(");")
-		} else {
-			sb.WriteString("),")
+		// Close the statement at the end of each batch
+		end := ")"
+		if (i+1)%batch == 0 || i == so.Count-1 {
+			end += conflict + ";"
+		}
+
+		// Writes keep failing after the first error, so checking the last write of the row is enough
+		if _, err := sb.WriteString(end); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(i+1, so.Count)
 		}
 	}
 
-	return sb.String(), nil
+	return sb.Flush()
 }
 
 // sqlConvertType will take in a type and value and convert it to the proper SQL type
@@ -100,6 +221,304 @@ func sqlConvertType(t string, val any) string {
 	}
 }
 
+// sqlCreateTable will output a create table statement with column types inferred from each fields
+// output type, a primary key on autoincrement fields, NOT NULL columns and any extra constraints
+func sqlCreateTable(dialect string, so *SQLOptions, outputs []string, constraints []string) string {
+	var sb strings.Builder
+	sb.WriteString("CREATE TABLE " + sqlIdent(dialect, so.Table) + " (")
+
+	var keys []string
+	for i, field := range so.Fields {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+
+		col := sqlIdent(dialect, field.Name)
+		sb.WriteString("\n\t" + col + " " + sqlColumnType(dialect, outputs[i]))
+		if !stringInSlice(field.Name, so.Nullable) {
+			sb.WriteString(" NOT NULL")
+		}
+
+		if field.Function == "autoincrement" {
+			keys = append(keys, col)
+		}
+	}
+
+	if len(keys) > 0 {
+		sb.WriteString(",\n\tPRIMARY KEY (" + strings.Join(keys, ", ") + ")")
+	}
+	for _, constraint := range constraints {
+		sb.WriteString(",\n\t" + constraint)
+	}
+	sb.WriteString("\n);")
+
+	return sb.String()
+}
+
+// sqlFieldOutput will return the output type a field generates without running it
+func sqlFieldOutput(f *Faker, field *Field) string {
+	switch {
+	case field.Function == "autoincrement":
+		return "int"
+	case field.Value != nil:
+		return fmt.Sprintf("%T", field.Value)
+	case len(field.Fields) > 0 || field.Ref != "" || field.Count > 0 || field.CountMax > 0:
+		return "map[string]any"
+	}
+
+	if info := f.GetFuncLookup(field.Function); info != nil {
+		return info.Output
+	}
+
+	// Generate patterns always output a string
+	return "string"
+}
+
+// sqlColumnType will map a function output type to a column type for the dialect
+func sqlColumnType(dialect string, output string) string {
+	// Column types for postgres, mysql, sqlite and sqlserver
+	var types [4]string
+	switch output {
+	case "bool":
+		types = [4]string{"BOOLEAN", "BOOLEAN", "INTEGER", "BIT"}
+	case "int", "int8", "int16", "int32", "uint8", "uint16", "byte":
+		types = [4]string{"INTEGER", "INTEGER", "INTEGER", "INT"}
+	case "int64", "uint", "uint32", "uint64":
+		types = [4]string{"BIGINT", "BIGINT", "INTEGER", "BIGINT"}
+	case "float", "float32", "float64":
+		types = [4]string{"NUMERIC", "DOUBLE", "REAL", "FLOAT"}
+	case "time", "time.Time", "*time.Time":
+		types = [4]string{"TIMESTAMP WITH TIME ZONE", "DATETIME(6)", "DATETIME", "DATETIME2"}
+	case "[]byte", "[]uint8":
+		types = [4]string{"BYTEA", "LONGBLOB", "BLOB", "VARBINARY(MAX)"}
+	case "string", "net.IP":
+		types = [4]string{"TEXT", "VARCHAR(255)", "TEXT", "NVARCHAR(255)"}
+	default:
+		// Maps, slices and structs are stored as json
+		types = [4]string{"JSONB", "JSON", "TEXT", "NVARCHAR(MAX)"}
+	}
+
+	switch dialect {
+	case "postgres":
+		return types[0]
+	case "mysql":
+		return types[1]
+	case "sqlite":
+		return types[2]
+	case "sqlserver":
+		return types[3]
+	}
+
+	// Without a dialect use the standard type names
+	switch types[0] {
+	case "TIMESTAMP WITH TIME ZONE":
+		return "TIMESTAMP"
+	case "BYTEA":
+		return "BLOB"
+	case "JSONB":
+		return "TEXT"
+	}
+	return types[0]
+}
+
+// sqlConflict will return the clause added to the end of each insert statement for on conflict
+func sqlConflict(dialect string, so *SQLOptions) (string, error) {
+	onConflict := strings.ToLower(so.OnConflict)
+	if onConflict == "" || onConflict == "none" {
+		return "", nil
+	}
+	if onConflict != "ignore" && onConflict != "update" {
+		return "", errors.New("invalid on conflict, must be ignore or update")
+	}
+	if dialect == "" || dialect == "sqlserver" {
+		return "", errors.New("on conflict requires a postgres, mysql or sqlite dialect")
+	}
+
+	// Unique columns default to the first field, which is usually the primary key
+	keys := so.ConflictColumns
+	if len(keys) == 0 {
+		keys = []string{so.Fields[0].Name}
+	}
+
+	// Update every column that is not a unique column
+	var set []string
+	for _, field := range so.Fields {
+		if stringInSlice(field.Name, keys) {
+			continue
+		}
+
+		col := sqlIdent(dialect, field.Name)
+		if dialect == "mysql" {
+			set = append(set, col+" = VALUES("+col+")")
+		} else {
+			set = append(set, col+" = EXCLUDED."+col)
+		}
+	}
+
+	if dialect == "mysql" {
+		if onConflict == "ignore" {
+			// Added to the start of the statement as INSERT IGNORE
+			return "", nil
+		}
+		if len(set) == 0 {
+			col := sqlIdent(dialect, keys[0])
+			set = append(set, col+" = "+col)
+		}
+		return " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", "), nil
+	}
+
+	if onConflict == "ignore" || len(set) == 0 {
+		return " ON CONFLICT DO NOTHING", nil
+	}
+
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = sqlIdent(dialect, key)
+	}
+
+	return " ON CONFLICT (" + strings.Join(quoted, ", ") + ") DO UPDATE SET " + strings.Join(set, ", "), nil
+}
+
+// sqlIdent will quote a table or column name for the dialect, quoting each part of dotted names.
+// Ex: public.users - "public"."users"
+func sqlIdent(dialect string, name string) string {
+	if dialect == "" {
+		return name
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		switch dialect {
+		case "mysql":
+			parts[i] = "`" + strings.ReplaceAll(part, "`", "``") + "`"
+		case "sqlserver":
+			parts[i] = "[" + strings.ReplaceAll(part, "]", "]]") + "]"
+		default:
+			parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// sqlLiteral will convert a value into a literal for the dialect
+func sqlLiteral(dialect string, val any) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return sqlString(dialect, v), nil
+	case []byte:
+		// Byte slices are usually text like json, anything else is binary
+		if utf8.Valid(v) {
+			return sqlString(dialect, string(v)), nil
+		}
+		return sqlBinary(dialect, v), nil
+	case bool:
+		return sqlBool(dialect, v), nil
+	case time.Time:
+		return sqlString(dialect, v.Format(sqlTimeLayout(dialect))), nil
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return sqlLiteral(dialect, rv.Elem().Interface())
+	case reflect.Bool:
+		return sqlBool(dialect, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		fl := rv.Float()
+		if math.IsNaN(fl) || math.IsInf(fl, 0) {
+			return "NULL", nil
+		}
+		return strconv.FormatFloat(fl, 'f', -1, rv.Type().Bits()), nil
+	case reflect.String:
+		return sqlString(dialect, rv.String()), nil
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		// Store structured values as json text
+		if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Array && rv.IsNil() {
+			return "NULL", nil
+		}
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return sqlString(dialect, string(b)), nil
+	}
+
+	return sqlString(dialect, fmt.Sprintf("%v", val)), nil
+}
+
+// sqlString will quote and escape a string literal for the dialect
+func sqlString(dialect string, s string) string {
+	switch dialect {
+	case "mysql":
+		// Backslashes are escape characters unless NO_BACKSLASH_ESCAPES is set
+		s = strings.NewReplacer(`\`, `\\`, `'`, `''`, "\x00", `\0`).Replace(s)
+		return `'` + s + `'`
+	case "sqlserver":
+		s = `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+		for _, r := range s {
+			if r >= utf8.RuneSelf {
+				// Unicode strings need the N prefix to keep non ascii characters
+				return "N" + s
+			}
+		}
+		return s
+	default:
+		return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+	}
+}
+
+// sqlBinary will output a binary literal for the dialect
+func sqlBinary(dialect string, b []byte) string {
+	switch dialect {
+	case "postgres":
+		return `'\x` + hex.EncodeToString(b) + `'::bytea`
+	case "sqlserver":
+		return "0x" + strings.ToUpper(hex.EncodeToString(b))
+	default:
+		return "X'" + strings.ToUpper(hex.EncodeToString(b)) + "'"
+	}
+}
+
+// sqlBool will output a boolean literal for the dialect
+func sqlBool(dialect string, b bool) string {
+	switch dialect {
+	case "postgres", "mysql":
+		if b {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+}
+
+// sqlTimeLayout is the timestamp literal layout for the dialect
+func sqlTimeLayout(dialect string) string {
+	switch dialect {
+	case "postgres":
+		return "2006-01-02 15:04:05.999999-07:00"
+	case "mysql":
+		return "2006-01-02 15:04:05.999999"
+	case "sqlserver":
+		return "2006-01-02T15:04:05.9999999"
+	default:
+		return "2006-01-02 15:04:05.999"
+	}
+}
+
 func addDatabaseSQLLookup() {
 	AddFuncLookup("sql", Info{
 		Display:     "SQL",
@@ -114,8 +533,14 @@ VALUES
 		ContentType: "application/sql",
 		Params: []Param{
 			{Field: "table", Display: "Table", Type: "string", Description: "Name of the table to insert into"},
-			{Field: "count", Display: "Count", Type: "int", Default: "100", Description: "Number of inserts to generate"},
+			{Field: "count", Display: "Count", Type: "int", Default: "100", Min: "1", Description: "Number of inserts to generate"},
 			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
+			{Field: "dialect", Display: "Dialect", Type: "string", Optional: true, Options: []string{"postgres", "mysql", "sqlite", "sqlserver"}, Description: "SQL dialect used to quote names and values"},
+			{Field: "batchsize", Display: "Batch Size", Type: "int", Default: "0", Min: "0", Description: "Number of rows per insert statement, 0 for all rows in one statement"},
+			{Field: "onconflict", Display: "On Conflict", Type: "string", Default: "none", Options: []string{"none", "ignore", "update"}, Description: "What to do with rows that already exist, requires a dialect"},
+			{Field: "conflictcolumns", Display: "Conflict Columns", Type: "[]string", Optional: true, Description: "Unique columns checked on conflict, defaults to the first field"},
+			{Field: "createtable", Display: "Create Table", Type: "bool", Default: "false", Description: "Whether or not to output a create table statement before the inserts"},
+			{Field: "nullable", Display: "Nullable", Type: "[]string", Optional: true, Description: "Columns created without NOT NULL"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			so := SQLOptions{}
@@ -132,6 +557,30 @@ VALUES
 			}
 			so.Count = count
 
+			so.Dialect, _ = info.GetString(m, "dialect")
+
+			batchSize, err := info.GetInt(m, "batchsize")
+			if err != nil {
+				return nil, err
+			}
+			so.BatchSize = batchSize
+
+			onConflict, err := info.GetString(m, "onconflict")
+			if err != nil {
+				return nil, err
+			}
+			so.OnConflict = onConflict
+
+			so.ConflictColumns, _ = info.GetStringArray(m, "conflictcolumns")
+
+			createTable, err := info.GetBool(m, "createtable")
+			if err != nil {
+				return nil, err
+			}
+			so.CreateTable = createTable
+
+			so.Nullable, _ = info.GetStringArray(m, "nullable")
+
 			fieldsStr, err := info.GetStringArray(m, "fields")
 			if err != nil {
 				return nil, err
@@ -1,9 +1,12 @@
 package gofakeit
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
+	"time"
 )
 
 func ExampleSQL() {
@@ -46,6 +49,25 @@ func ExampleFaker_SQL() {
 	// Output: INSERT INTO people (id, first_name, price, age, created_at) VALUES (1, 'Sonny', 985.96, 20, '1925-07-08 17:32:57'),(2, 'Steve', 639.32, 74, '2016-12-29 07:25:24');
 }
 
+func ExampleSQL_postgres() {
+	f := New(11)
+
+	res, _ := f.SQL(&SQLOptions{
+		Table:      "people",
+		Count:      2,
+		Dialect:    "postgres",
+		OnConflict: "update",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "active", Function: "bool"},
+		},
+	})
+	fmt.Println(res)
+
+	// Output: INSERT INTO "people" ("id", "first_name", "active") VALUES (1, 'Sonny', FALSE),(2, 'Cody', TRUE) ON CONFLICT ("id") DO UPDATE SET "first_name" = EXCLUDED."first_name", "active" = EXCLUDED."active";
+}
+
 func TestSQLJSON(t *testing.T) {
 	Seed(11)
 
@@ -211,3 +233,295 @@ func TestSQLNilTable(t *testing.T) {
 		t.Fatal("should have failed for no table")
 	}
 }
+
+func TestSQLNilOptions(t *testing.T) {
+	if _, err := SQL(nil); err == nil {
+		t.Error("should have failed for nil options")
+	}
+	if err := WriteSQL(context.Background(), io.Discard, nil, nil); err == nil {
+		t.Error("should have failed for nil options")
+	}
+}
+
+func TestWriteSQL(t *testing.T) {
+	so := &SQLOptions{
+		Table: "people",
+		Count: 50,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "price", Function: "price"},
+		},
+	}
+
+	// Streaming output matches the in memory output
+	want, err := New(11).SQL(so)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := New(11).WriteSQL(context.Background(), &sb, so, nil); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != want {
+		t.Errorf("expected streamed sql to match SQL\n%s\n%s", sb.String(), want)
+	}
+}
+
+func TestSQLDialects(t *testing.T) {
+	tests := map[string]string{
+		"postgres": `INSERT INTO "public"."users" ("id", "name", "active", "created_at", "data", "score", "deleted_at") VALUES (1, 'O''Brien \ café', TRUE, '2024-01-02 03:04:05.123+00:00', '\xdeadbeef'::bytea, 1.5, NULL),(2, 'O''Brien \ café', TRUE, '2024-01-02 03:04:05.123+00:00', '\xdeadbeef'::bytea, 1.5, NULL);
+INSERT INTO "public"."users" ("id", "name", "active", "created_at", "data", "score", "deleted_at") VALUES (3, 'O''Brien \ café', TRUE, '2024-01-02 03:04:05.123+00:00', '\xdeadbeef'::bytea, 1.5, NULL);`,
+		"mysql": "INSERT INTO `public`.`users` (`id`, `name`, `active`, `created_at`, `data`, `score`, `deleted_at`) VALUES (1, 'O''Brien \\\\ café', TRUE, '2024-01-02 03:04:05.123', X'DEADBEEF', 1.5, NULL),(2, 'O''Brien \\\\ café', TRUE, '2024-01-02 03:04:05.123', X'DEADBEEF', 1.5, NULL);\n" +
+			"INSERT INTO `public`.`users` (`id`, `name`, `active`, `created_at`, `data`, `score`, `deleted_at`) VALUES (3, 'O''Brien \\\\ café', TRUE, '2024-01-02 03:04:05.123', X'DEADBEEF', 1.5, NULL);",
+		"sqlite": `INSERT INTO "public"."users" ("id", "name", "active", "created_at", "data", "score", "deleted_at") VALUES (1, 'O''Brien \ café', 1, '2024-01-02 03:04:05.123', X'DEADBEEF', 1.5, NULL),(2, 'O''Brien \ café', 1, '2024-01-02 03:04:05.123', X'DEADBEEF', 1.5, NULL);
+INSERT INTO "public"."users" ("id", "name", "active", "created_at", "data", "score", "deleted_at") VALUES (3, 'O''Brien \ café', 1, '2024-01-02 03:04:05.123', X'DEADBEEF', 1.5, NULL);`,
+		"sqlserver": `INSERT INTO [public].[users] ([id], [name], [active], [created_at], [data], [score], [deleted_at]) VALUES (1, N'O''Brien \ café', 1, '2024-01-02T03:04:05.123', 0xDEADBEEF, 1.5, NULL),(2, N'O''Brien \ café', 1, '2024-01-02T03:04:05.123', 0xDEADBEEF, 1.5, NULL);
+INSERT INTO [public].[users] ([id], [name], [active], [created_at], [data], [score], [deleted_at]) VALUES (3, N'O''Brien \ café', 1, '2024-01-02T03:04:05.123', 0xDEADBEEF, 1.5, NULL);`,
+	}
+
+	for dialect, want := range tests {
+		f := New(11)
+		f.AddFuncLookup("nothing", Info{
+			Output: "string",
+			Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+				return nil, nil
+			},
+		})
+
+		got, err := f.SQL(&SQLOptions{
+			Table:     "public.users",
+			Count:     3,
+			BatchSize: 2,
+			Dialect:   dialect,
+			Fields: []Field{
+				{Name: "id", Function: "autoincrement"},
+				{Name: "name", Value: `O'Brien \ café`},
+				{Name: "active", Value: true},
+				{Name: "created_at", Value: time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC)},
+				{Name: "data", Value: []byte{0xde, 0xad, 0xbe, 0xef}},
+				{Name: "score", Value: 1.5},
+				{Name: "deleted_at", Function: "nothing"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", dialect, err)
+		}
+		if got != want {
+			t.Errorf("%s:\ngot  %s\nwant %s", dialect, got, want)
+		}
+	}
+}
+
+func TestSQLOnConflict(t *testing.T) {
+	tests := []struct {
+		dialect    string
+		onConflict string
+		columns    []string
+		want       string
+	}{
+		{"postgres", "ignore", nil, `INSERT INTO "users" ("id", "email", "name") VALUES (1, 'a@b.c', 'Al') ON CONFLICT DO NOTHING;`},
+		{"postgres", "update", nil, `INSERT INTO "users" ("id", "email", "name") VALUES (1, 'a@b.c', 'Al') ON CONFLICT ("id") DO UPDATE SET "email" = EXCLUDED."email", "name" = EXCLUDED."name";`},
+		{"sqlite", "update", []string{"email"}, `INSERT INTO "users" ("id", "email", "name") VALUES (1, 'a@b.c', 'Al') ON CONFLICT ("email") DO UPDATE SET "id" = EXCLUDED."id", "name" = EXCLUDED."name";`},
+		{"sqlite", "update", []string{"id", "email", "name"}, `INSERT INTO "users" ("id", "email", "name") VALUES (1, 'a@b.c', 'Al') ON CONFLICT DO NOTHING;`},
+		{"mysql", "ignore", nil, "INSERT IGNORE INTO `users` (`id`, `email`, `name`) VALUES (1, 'a@b.c', 'Al');"},
+		{"mysql", "update", []string{"id", "email"}, "INSERT INTO `users` (`id`, `email`, `name`) VALUES (1, 'a@b.c', 'Al') ON DUPLICATE KEY UPDATE `name` = VALUES(`name`);"},
+	}
+
+	for _, tt := range tests {
+		got, err := New(11).SQL(&SQLOptions{
+			Table:           "users",
+			Count:           1,
+			Dialect:         tt.dialect,
+			OnConflict:      tt.onConflict,
+			ConflictColumns: tt.columns,
+			Fields: []Field{
+				{Name: "id", Function: "autoincrement"},
+				{Name: "email", Value: "a@b.c"},
+				{Name: "name", Value: "Al"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.dialect, tt.onConflict, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s %s:\ngot  %s\nwant %s", tt.dialect, tt.onConflict, got, tt.want)
+		}
+	}
+}
+
+func TestSQLBatchSize(t *testing.T) {
+	fields := []Field{{Name: "id", Function: "autoincrement"}}
+
+	got, err := New(11).SQL(&SQLOptions{Table: "t", Count: 5, BatchSize: 2, Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "INSERT INTO t (id) VALUES (1),(2);\nINSERT INTO t (id) VALUES (3),(4);\nINSERT INTO t (id) VALUES (5);"
+	if got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+
+	// SQL Server allows at most 1000 rows per statement
+	got, err = New(11).SQL(&SQLOptions{Table: "t", Count: 1001, Dialect: "sqlserver", Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(got, "INSERT INTO") != 2 || !strings.HasSuffix(got, "\nINSERT INTO [t] ([id]) VALUES (1001);") {
+		t.Errorf("expected 2 statements, got %d", strings.Count(got, "INSERT INTO"))
+	}
+}
+
+func TestSQLDialectErrors(t *testing.T) {
+	fields := []Field{{Name: "id", Function: "autoincrement"}}
+
+	tests := map[string]*SQLOptions{
+		"invalid dialect":     {Table: "t", Count: 1, Fields: fields, Dialect: "oracle"},
+		"invalid on conflict": {Table: "t", Count: 1, Fields: fields, Dialect: "postgres", OnConflict: "replace"},
+		"conflict no dialect": {Table: "t", Count: 1, Fields: fields, OnConflict: "ignore"},
+		"conflict sql server": {Table: "t", Count: 1, Fields: fields, Dialect: "sqlserver", OnConflict: "update"},
+	}
+
+	for name, so := range tests {
+		if _, err := SQL(so); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func ExampleSQL_createTable() {
+	f := New(11)
+
+	res, err := f.SQL(&SQLOptions{
+		Table:       "people",
+		Count:       2,
+		Dialect:     "postgres",
+		CreateTable: true,
+		Nullable:    []string{"nickname"},
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "nickname", Function: "firstname"},
+			{Name: "age", Function: "number", Params: MapParams{"min": {"18"}, "max": {"90"}}},
+			{Name: "verified", Function: "bool"},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(res)
+
+	// Output: CREATE TABLE "people" (
+	// 	"id" INTEGER NOT NULL,
+	// 	"first_name" TEXT NOT NULL,
+	// 	"nickname" TEXT,
+	// 	"age" INTEGER NOT NULL,
+	// 	"verified" BOOLEAN NOT NULL,
+	// 	PRIMARY KEY ("id")
+	// );
+	// INSERT INTO "people" ("id", "first_name", "nickname", "age", "verified") VALUES (1, 'Sonny', 'Russ', 32, TRUE),(2, 'Julius', 'Darien', 71, FALSE);
+}
+
+func TestSQLCreateTable(t *testing.T) {
+	tests := map[string]string{
+		"": `CREATE TABLE users (
+	id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	age INTEGER NOT NULL,
+	big BIGINT NOT NULL,
+	price NUMERIC,
+	created_at TIMESTAMP NOT NULL,
+	active BOOLEAN NOT NULL,
+	data BLOB NOT NULL,
+	tags TEXT NOT NULL,
+	code TEXT NOT NULL,
+	PRIMARY KEY (id)
+);`,
+		"postgres": `CREATE TABLE "users" (
+	"id" INTEGER NOT NULL,
+	"name" TEXT NOT NULL,
+	"age" INTEGER NOT NULL,
+	"big" BIGINT NOT NULL,
+	"price" NUMERIC,
+	"created_at" TIMESTAMP WITH TIME ZONE NOT NULL,
+	"active" BOOLEAN NOT NULL,
+	"data" BYTEA NOT NULL,
+	"tags" JSONB NOT NULL,
+	"code" TEXT NOT NULL,
+	PRIMARY KEY ("id")
+);`,
+		"mysql": "CREATE TABLE `users` (\n" +
+			"\t`id` INTEGER NOT NULL,\n" +
+			"\t`name` VARCHAR(255) NOT NULL,\n" +
+			"\t`age` INTEGER NOT NULL,\n" +
+			"\t`big` BIGINT NOT NULL,\n" +
+			"\t`price` DOUBLE,\n" +
+			"\t`created_at` DATETIME(6) NOT NULL,\n" +
+			"\t`active` BOOLEAN NOT NULL,\n" +
+			"\t`data` LONGBLOB NOT NULL,\n" +
+			"\t`tags` JSON NOT NULL,\n" +
+			"\t`code` VARCHAR(255) NOT NULL,\n" +
+			"\tPRIMARY KEY (`id`)\n" +
+			");",
+		"sqlite": `CREATE TABLE "users" (
+	"id" INTEGER NOT NULL,
+	"name" TEXT NOT NULL,
+	"age" INTEGER NOT NULL,
+	"big" INTEGER NOT NULL,
+	"price" REAL,
+	"created_at" DATETIME NOT NULL,
+	"active" INTEGER NOT NULL,
+	"data" BLOB NOT NULL,
+	"tags" TEXT NOT NULL,
+	"code" TEXT NOT NULL,
+	PRIMARY KEY ("id")
+);`,
+		"sqlserver": `CREATE TABLE [users] (
+	[id] INT NOT NULL,
+	[name] NVARCHAR(255) NOT NULL,
+	[age] INT NOT NULL,
+	[big] BIGINT NOT NULL,
+	[price] FLOAT,
+	[created_at] DATETIME2 NOT NULL,
+	[active] BIT NOT NULL,
+	[data] VARBINARY(MAX) NOT NULL,
+	[tags] NVARCHAR(MAX) NOT NULL,
+	[code] NVARCHAR(255) NOT NULL,
+	PRIMARY KEY ([id])
+);`,
+	}
+
+	fields := []Field{
+		{Name: "id", Function: "autoincrement"},
+		{Name: "name", Function: "name"},
+		{Name: "age", Function: "int8"},
+		{Name: "big", Function: "int64"},
+		{Name: "price", Function: "price"},
+		{Name: "created_at", Function: "pastdate"},
+		{Name: "active", Function: "bool"},
+		{Name: "data", Value: []byte("data")},
+		{Name: "tags", Function: "word", Count: 2},
+		{Name: "code", Function: "###-???"},
+	}
+
+	for _, dialect := range []string{"", "postgres", "mysql", "sqlite", "sqlserver"} {
+		got, err := New(11).SQL(&SQLOptions{
+			Table:       "users",
+			Count:       1,
+			Dialect:     dialect,
+			CreateTable: true,
+			Nullable:    []string{"price"},
+			Fields:      fields,
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", dialect, err)
+		}
+
+		got = got[:strings.Index(got, "\nINSERT")]
+		if want, ok := tests[dialect]; !ok || got != want {
+			t.Errorf("%s:\ngot  %s\nwant %s", dialect, got, want)
+		}
+	}
+}
@@ -256,7 +256,7 @@ func addStringLookup() {
 		Example:     "hello,world,whats,up => world",
 		Output:      "[]string",
 		Params: []Param{
-			{Field: "strs", Display: "Strings", Type: "[]string", Description: "Delimited separated strings"},
+			{Field: "strs", Display: "Strings", Type: "[]string", MinItems: 1, Description: "Delimited separated strings"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			strs, err := info.GetStringArray(m, "strs")
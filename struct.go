@@ -79,7 +79,7 @@ func rCustom(f *Faker, v reflect.Value, tag string) error {
 	}
 
 	fName, fParams := parseNameAndParamsFromTag(tag)
-	info := GetFuncLookup(fName)
+	info := f.GetFuncLookup(fName)
 
 	// Check to see if it's a replaceable lookup function
 	if info == nil {
@@ -93,7 +93,7 @@ func rCustom(f *Faker, v reflect.Value, tag string) error {
 	}
 
 	// Call function
-	fValue, err := info.Generate(f, mapParams, info)
+	fValue, err := f.callFuncLookup(fName, mapParams, info)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,311 @@
+package gofakeit
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// TableOptions defines values needed for markdown and html table generation
+type TableOptions struct {
+	RowCount int      `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
+	Fields   []Field  `json:"fields" xml:"fields" fake:"{fields}"`
+	Align    []string `json:"align" xml:"align" fake:"skip"`     // left, center or right for each field, in order
+	Caption  string   `json:"caption" xml:"caption" fake:"skip"` // Optional caption for the table
+}
+
+// MarkdownTable generates a markdown table of random data with columns padded to their widest value.
+// A nil TableOptions returns a randomly structured table.
+func MarkdownTable(to *TableOptions) (string, error) { return markdownTableFunc(GlobalFaker, to) }
+
+// MarkdownTable generates a markdown table of random data with columns padded to their widest value.
+// A nil TableOptions returns a randomly structured table.
+func (f *Faker) MarkdownTable(to *TableOptions) (string, error) { return markdownTableFunc(f, to) }
+
+// HTMLTable generates an html table of random data with a header row and escaped values.
+// A nil TableOptions returns a randomly structured table.
+func HTMLTable(to *TableOptions) (string, error) { return htmlTableFunc(GlobalFaker, to) }
+
+// HTMLTable generates an html table of random data with a header row and escaped values.
+// A nil TableOptions returns a randomly structured table.
+func (f *Faker) HTMLTable(to *TableOptions) (string, error) { return htmlTableFunc(f, to) }
+
+func markdownTableFunc(f *Faker, to *TableOptions) (string, error) {
+	to, rows, err := tableRows(f, to)
+	if err != nil {
+		return "", err
+	}
+
+	// Escape values so they stay in their cell
+	for _, row := range rows {
+		for i, value := range row {
+			value = strings.ReplaceAll(value, "|", `\|`)
+			value = strings.ReplaceAll(value, "\r\n", "<br>")
+			row[i] = strings.ReplaceAll(value, "\n", "<br>")
+		}
+	}
+
+	// Calculate column widths, at least 3 for the separator row
+	widths := make([]int, len(to.Fields))
+	for _, row := range rows {
+		for i, value := range row {
+			if width := utf8.RuneCountInString(value); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+	for i := range widths {
+		if widths[i] < 3 {
+			widths[i] = 3
+		}
+	}
+
+	var sb strings.Builder
+	if to.Caption != "" {
+		sb.WriteString("Table: " + to.Caption + "\n\n")
+	}
+
+	for i, row := range rows {
+		markdownTableRow(&sb, row, widths, to.Align)
+
+		// Separator row after the header with the alignment of each column
+		if i == 0 {
+			separator := make([]string, len(widths))
+			for ii, width := range widths {
+				switch tableAlign(to.Align, ii) {
+				case "left":
+					separator[ii] = ":" + strings.Repeat("-", width-1)
+				case "center":
+					separator[ii] = ":" + strings.Repeat("-", width-2) + ":"
+				case "right":
+					separator[ii] = strings.Repeat("-", width-1) + ":"
+				default:
+					separator[ii] = strings.Repeat("-", width)
+				}
+			}
+			sb.WriteString("\n")
+			markdownTableRow(&sb, separator, widths, nil)
+		}
+
+		// Only add new line if not the last row
+		if i != len(rows)-1 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// markdownTableRow will write a row with each value padded to its column width based on its alignment
+func markdownTableRow(sb *strings.Builder, row []string, widths []int, align []string) {
+	sb.WriteString("|")
+	for i, value := range row {
+		pad := widths[i] - utf8.RuneCountInString(value)
+		switch tableAlign(align, i) {
+		case "right":
+			value = strings.Repeat(" ", pad) + value
+		case "center":
+			value = strings.Repeat(" ", pad/2) + value + strings.Repeat(" ", pad-pad/2)
+		default:
+			value = value + strings.Repeat(" ", pad)
+		}
+		sb.WriteString(" " + value + " |")
+	}
+}
+
+func htmlTableFunc(f *Faker, to *TableOptions) (string, error) {
+	to, rows, err := tableRows(f, to)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n")
+	if to.Caption != "" {
+		sb.WriteString("  <caption>" + html.EscapeString(to.Caption) + "</caption>\n")
+	}
+
+	for i, row := range rows {
+		cell := "td"
+		if i == 0 {
+			cell = "th"
+			sb.WriteString("  <thead>\n")
+		}
+		if i == 1 {
+			sb.WriteString("  <tbody>\n")
+		}
+
+		sb.WriteString("    <tr>\n")
+		for ii, value := range row {
+			sb.WriteString("      <" + cell)
+			if align := tableAlign(to.Align, ii); align != "" {
+				sb.WriteString(` style="text-align: ` + align + `"`)
+			}
+			sb.WriteString(">" + html.EscapeString(value) + "</" + cell + ">\n")
+		}
+		sb.WriteString("    </tr>\n")
+
+		if i == 0 {
+			sb.WriteString("  </thead>\n")
+		}
+		if i > 0 && i == len(rows)-1 {
+			sb.WriteString("  </tbody>\n")
+		}
+	}
+	sb.WriteString("</table>")
+
+	return sb.String(), nil
+}
+
+// tableRows will generate the header row followed by a row of string values for each row count
+func tableRows(f *Faker, to *TableOptions) (*TableOptions, [][]string, error) {
+	// If we didn't get TableOptions, create a new random one
+	if to == nil {
+		to = &TableOptions{}
+	}
+
+	// Make sure you set a row count
+	if to.RowCount <= 0 {
+		to.RowCount = f.IntN(10) + 1
+	}
+
+	// Check fields
+	if len(to.Fields) <= 0 {
+		// Create random fields
+		to.Fields = []Field{
+			{Name: "Name", Function: "{firstname} {lastname}"},
+			{Name: "Email", Function: "email"},
+			{Name: "Age", Function: "number", Params: MapParams{"min": {"18"}, "max": {"90"}}},
+		}
+	}
+
+	for _, align := range to.Align {
+		if align != "" && align != "left" && align != "center" && align != "right" {
+			return nil, nil, errors.New("invalid align, must be left, center or right")
+		}
+	}
+
+	rows := make([][]string, to.RowCount+1)
+	rows[0] = make([]string, len(to.Fields))
+	for i, field := range to.Fields {
+		rows[0][i] = field.Name
+	}
+
+	for i := 1; i <= to.RowCount; i++ {
+		obj, err := f.fieldObject(to.Fields, i, 0, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows[i] = make([]string, len(obj))
+		for ii, kv := range obj {
+			rows[i][ii] = anyToString(kv.Value)
+		}
+	}
+
+	return to, rows, nil
+}
+
+// tableAlign will return the alignment of a column or an empty string if it isnt set
+func tableAlign(align []string, i int) string {
+	if i >= len(align) {
+		return ""
+	}
+
+	return align[i]
+}
+
+func addFileTableLookup() {
+	AddFuncLookup("markdowntable", Info{
+		Display:     "Markdown Table",
+		Category:    "file",
+		Description: "Table of rows in markdown format with columns padded to their widest value",
+		Example: `| Name           | Email                      | Age |
+| -------------- | -------------------------- | --- |
+| Markus Moen    | sylvanmraz@murphy.net      | 34  |
+| Alayna Wuckert | santinostanton@carroll.biz | 46  |`,
+		Output:      "string",
+		ContentType: "text/markdown",
+		Params:      tableLookupParams,
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			to, err := tableLookupOptions(m, info)
+			if err != nil {
+				return nil, err
+			}
+
+			return markdownTableFunc(f, to)
+		},
+	})
+
+	AddFuncLookup("htmltable", Info{
+		Display:     "HTML Table",
+		Category:    "file",
+		Description: "Table of rows in html format with a header row and escaped values",
+		Example: `<table>
+  <thead>
+    <tr>
+      <th>Name</th>
+      <th>Email</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <td>Markus Moen</td>
+      <td>sylvanmraz@murphy.net</td>
+    </tr>
+  </tbody>
+</table>`,
+		Output:      "string",
+		ContentType: "text/html",
+		Params:      tableLookupParams,
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			to, err := tableLookupOptions(m, info)
+			if err != nil {
+				return nil, err
+			}
+
+			return htmlTableFunc(f, to)
+		},
+	})
+}
+
+var tableLookupParams = []Param{
+	{Field: "rowcount", Display: "Row Count", Type: "int", Default: "10", Description: "Number of rows"},
+	{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields name, function and params"},
+	{Field: "align", Display: "Align", Type: "[]string", Optional: true, Options: []string{"left", "center", "right"}, Description: "Alignment of each column, left, center or right"},
+	{Field: "caption", Display: "Caption", Type: "string", Optional: true, Description: "Caption for the table"},
+}
+
+// tableLookupOptions will get the table options from the lookup params
+func tableLookupOptions(m *MapParams, info *Info) (*TableOptions, error) {
+	to := TableOptions{}
+
+	rowCount, err := info.GetInt(m, "rowcount")
+	if err != nil {
+		return nil, err
+	}
+	to.RowCount = rowCount
+
+	fields, _ := info.GetStringArray(m, "fields")
+
+	// Check to make sure fields has length
+	if len(fields) > 0 {
+		to.Fields = make([]Field, len(fields))
+		for i, f := range fields {
+			// Unmarshal fields string into fields array
+			err = json.Unmarshal([]byte(f), &to.Fields[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		return nil, errors.New("missing fields")
+	}
+
+	to.Align, _ = info.GetStringArray(m, "align")
+	to.Caption, _ = info.GetString(m, "caption")
+
+	return &to, nil
+}
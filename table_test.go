@@ -0,0 +1,287 @@
+package gofakeit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleMarkdownTable() {
+	Seed(11)
+
+	value, err := MarkdownTable(&TableOptions{
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "Name", Function: "{firstname} {lastname}"},
+			{Name: "Email", Function: "email"},
+			{Name: "Age", Function: "{number:1,100}"},
+		},
+		Align: []string{"left", "", "right"},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: | Name              | Email                    | Age |
+	// | :---------------- | ------------------------ | --: |
+	// | Sonny Stiedemann  | codydonnelly@leannon.biz |  73 |
+	// | Kaitlyn Wilderman | rahulturner@bins.org     |  94 |
+	// | Tomasa Ullrich    | ernestjones@jast.org     |  31 |
+}
+
+func ExampleFaker_MarkdownTable() {
+	f := New(11)
+
+	value, err := f.MarkdownTable(&TableOptions{
+		RowCount: 3,
+		Fields: []Field{
+			{Name: "Name", Function: "{firstname} {lastname}"},
+			{Name: "Email", Function: "email"},
+			{Name: "Age", Function: "{number:1,100}"},
+		},
+		Align: []string{"left", "", "right"},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: | Name              | Email                    | Age |
+	// | :---------------- | ------------------------ | --: |
+	// | Sonny Stiedemann  | codydonnelly@leannon.biz |  73 |
+	// | Kaitlyn Wilderman | rahulturner@bins.org     |  94 |
+	// | Tomasa Ullrich    | ernestjones@jast.org     |  31 |
+}
+
+func ExampleHTMLTable() {
+	Seed(11)
+
+	value, err := HTMLTable(&TableOptions{
+		RowCount: 2,
+		Caption:  "Users",
+		Fields: []Field{
+			{Name: "Name", Function: "{firstname} {lastname}"},
+			{Name: "Age", Function: "{number:1,100}"},
+		},
+		Align: []string{"", "right"},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: <table>
+	//   <caption>Users</caption>
+	//   <thead>
+	//     <tr>
+	//       <th>Name</th>
+	//       <th style="text-align: right">Age</th>
+	//     </tr>
+	//   </thead>
+	//   <tbody>
+	//     <tr>
+	//       <td>Sonny Stiedemann</td>
+	//       <td style="text-align: right">20</td>
+	//     </tr>
+	//     <tr>
+	//       <td>Cole Leannon</td>
+	//       <td style="text-align: right">24</td>
+	//     </tr>
+	//   </tbody>
+	// </table>
+}
+
+func ExampleFaker_HTMLTable() {
+	f := New(11)
+
+	value, err := f.HTMLTable(&TableOptions{
+		RowCount: 2,
+		Caption:  "Users",
+		Fields: []Field{
+			{Name: "Name", Function: "{firstname} {lastname}"},
+			{Name: "Age", Function: "{number:1,100}"},
+		},
+		Align: []string{"", "right"},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(value)
+
+	// Output: <table>
+	//   <caption>Users</caption>
+	//   <thead>
+	//     <tr>
+	//       <th>Name</th>
+	//       <th style="text-align: right">Age</th>
+	//     </tr>
+	//   </thead>
+	//   <tbody>
+	//     <tr>
+	//       <td>Sonny Stiedemann</td>
+	//       <td style="text-align: right">20</td>
+	//     </tr>
+	//     <tr>
+	//       <td>Cole Leannon</td>
+	//       <td style="text-align: right">24</td>
+	//     </tr>
+	//   </tbody>
+	// </table>
+}
+
+func TestMarkdownTable(t *testing.T) {
+	value, err := New(11).MarkdownTable(&TableOptions{
+		RowCount: 2,
+		Caption:  "Values",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "pipe", Value: "a|b"},
+			{Name: "lines", Value: "one\ntwo"},
+			{Name: "center", Value: "x"},
+		},
+		Align: []string{"right", "left", "", "center"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Table: Values
+
+|  id | pipe | lines      | center |
+| --: | :--- | ---------- | :----: |
+|   1 | a\|b | one<br>two |   x    |
+|   2 | a\|b | one<br>two |   x    |`
+	if value != want {
+		t.Errorf("unexpected markdown\ngot\n%s\nwant\n%s", value, want)
+	}
+}
+
+func TestMarkdownTableDefault(t *testing.T) {
+	value, err := New(11).MarkdownTable(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(value, "\n")
+	if len(lines) < 3 || !strings.HasPrefix(lines[0], "| Name ") {
+		t.Fatalf("unexpected markdown %s", value)
+	}
+
+	// Every line is padded to the same width
+	for _, line := range lines {
+		if len([]rune(line)) != len([]rune(lines[0])) {
+			t.Errorf("expected all lines to be %d wide, got %q", len([]rune(lines[0])), line)
+		}
+	}
+}
+
+func TestHTMLTable(t *testing.T) {
+	value, err := New(11).HTMLTable(&TableOptions{
+		RowCount: 1,
+		Caption:  "Tom & Jerry",
+		Fields: []Field{
+			{Name: "<id>", Function: "autoincrement"},
+			{Name: "html", Value: `<b class="x">'hi'</b>`},
+		},
+		Align: []string{"center"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<table>
+  <caption>Tom &amp; Jerry</caption>
+  <thead>
+    <tr>
+      <th style="text-align: center">&lt;id&gt;</th>
+      <th>html</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <td style="text-align: center">1</td>
+      <td>&lt;b class=&#34;x&#34;&gt;&#39;hi&#39;&lt;/b&gt;</td>
+    </tr>
+  </tbody>
+</table>`
+	if value != want {
+		t.Errorf("unexpected html\ngot\n%s\nwant\n%s", value, want)
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	f := New(11)
+
+	_, err := f.MarkdownTable(&TableOptions{RowCount: 1, Fields: []Field{{Name: "id", Function: "notafunction"}}})
+	if err == nil {
+		t.Error("expected error for invalid function")
+	}
+
+	_, err = f.HTMLTable(&TableOptions{RowCount: 1, Fields: []Field{{Name: "id", Function: "autoincrement"}}, Align: []string{"middle"}})
+	if err == nil {
+		t.Error("expected error for invalid align")
+	}
+}
+
+func TestTableLookup(t *testing.T) {
+	faker := New(11)
+
+	for _, name := range []string{"markdowntable", "htmltable"} {
+		info := GetFuncLookup(name)
+
+		m := MapParams{
+			"rowcount": {"3"},
+			"fields": {
+				`{"name":"first_name","function":"firstname"}`,
+				`{"name":"age","function":"number","params":{"min":["1"],"max":["99"]}}`,
+			},
+			"align":   {"left", "right"},
+			"caption": {"People"},
+		}
+
+		value, err := info.Generate(faker, &m, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(value.(string), "first_name") || !strings.Contains(value.(string), "People") {
+			t.Errorf("%s: unexpected value %s", name, value)
+		}
+
+		m = MapParams{"rowcount": {"3"}}
+		_, err = info.Generate(faker, &m, info)
+		if err == nil {
+			t.Errorf("%s: expected error for missing fields", name)
+		}
+	}
+}
+
+func BenchmarkMarkdownTable(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		MarkdownTable(&TableOptions{
+			RowCount: 10,
+			Fields: []Field{
+				{Name: "Name", Function: "{firstname} {lastname}"},
+				{Name: "Email", Function: "email"},
+				{Name: "Age", Function: "{number:1,100}"},
+			},
+		})
+	}
+}
+
+func BenchmarkHTMLTable(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		HTMLTable(&TableOptions{
+			RowCount: 10,
+			Fields: []Field{
+				{Name: "Name", Function: "{firstname} {lastname}"},
+				{Name: "Email", Function: "email"},
+				{Name: "Age", Function: "{number:1,100}"},
+			},
+		})
+	}
+}
@@ -153,9 +153,14 @@ func (f *Faker) EmailText(co *EmailOptions) (string, error) {
 
 // functions that wont work with template engine
 var templateExclusion = []string{
+	"GetFuncLookup",
 	"RandomMapKey",
 	"SQL",
 	"Template",
+	"WriteCSV",
+	"WriteNDJSON",
+	"WriteSQL",
+	"WriteXML",
 }
 
 // Build the template.FuncMap for the template engine
@@ -183,6 +188,39 @@ func templateFuncMap(f *Faker, fm *template.FuncMap) *template.FuncMap {
 		funcMap[v.Type().Method(i).Name] = v.Method(i).Interface()
 	}
 
+	// Route methods that match a lookup function through the fakers lookup
+	// registry so its overrides, removals and middleware apply to templates
+	f.funcLookupsLock.RLock()
+	if len(f.middleware) > 0 || len(f.funcLookups) > 0 {
+		for i := 0; i < v.NumMethod(); i++ {
+			method := v.Type().Method(i)
+			if _, ok := funcMap[method.Name]; !ok {
+				continue
+			}
+
+			fName := strings.ToLower(method.Name)
+			info, local := f.funcLookups[fName]
+			if local && info == nil {
+				// Removed for this faker
+				delete(funcMap, method.Name)
+				continue
+			}
+			if !local {
+				if len(f.middleware) == 0 {
+					continue
+				}
+
+				info = GetFuncLookup(fName)
+				if info == nil {
+					continue
+				}
+			}
+
+			funcMap[method.Name] = templateLookupFunc(f, fName, info, v.Method(i), local)
+		}
+	}
+	f.funcLookupsLock.RUnlock()
+
 	// make string upper case
 	funcMap["ToUpper"] = strings.ToUpper
 
@@ -307,6 +345,72 @@ func templateFuncMap(f *Faker, fm *template.FuncMap) *template.FuncMap {
 	return &funcMap
 }
 
+// templateLookupFunc wraps a faker method so template calls run through the lookup
+// middleware. If override is set the lookups generate function replaces the method
+func templateLookupFunc(f *Faker, name string, info *Info, method reflect.Value, override bool) any {
+	methodType := method.Type()
+	in := make([]reflect.Type, methodType.NumIn())
+	for i := range in {
+		in[i] = methodType.In(i)
+	}
+	anyType := reflect.TypeOf((*any)(nil)).Elem()
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	funcType := reflect.FuncOf(in, []reflect.Type{anyType, errType}, methodType.IsVariadic())
+
+	return reflect.MakeFunc(funcType, func(args []reflect.Value) []reflect.Value {
+		// Map template arguments onto the lookup params by position
+		var m *MapParams
+		for i, arg := range args {
+			if i >= len(info.Params) {
+				break
+			}
+			if m == nil {
+				m = NewMapParams()
+			}
+
+			switch arg.Kind() {
+			case reflect.Slice, reflect.Array:
+				for ii := 0; ii < arg.Len(); ii++ {
+					m.Add(info.Params[i].Field, fmt.Sprintf("%v", arg.Index(ii).Interface()))
+				}
+			case reflect.Ptr, reflect.Struct, reflect.Map, reflect.Func, reflect.Interface:
+				// Not representable as a param value
+			default:
+				m.Add(info.Params[i].Field, fmt.Sprintf("%v", arg.Interface()))
+			}
+		}
+
+		value, err := f.callFuncLookupWith(name, m, info, func(f *Faker, name string, m *MapParams, info *Info) (any, error) {
+			if override {
+				return info.Generate(f, m, info)
+			}
+
+			var out []reflect.Value
+			if methodType.IsVariadic() {
+				out = method.CallSlice(args)
+			} else {
+				out = method.Call(args)
+			}
+			if len(out) == 2 && !out[1].IsNil() {
+				return out[0].Interface(), out[1].Interface().(error)
+			}
+
+			return out[0].Interface(), nil
+		})
+
+		valueOut := reflect.Zero(anyType)
+		if value != nil {
+			valueOut = reflect.ValueOf(&value).Elem()
+		}
+		errOut := reflect.Zero(errType)
+		if err != nil {
+			errOut = reflect.ValueOf(&err).Elem()
+		}
+
+		return []reflect.Value{valueOut, errOut}
+	}).Interface()
+}
+
 // function to build the function map for the template engine from the global faker
 func templateFunc(temp string, funcs *template.FuncMap, data any) (string, error) {
 	if temp == "" {
@@ -0,0 +1,388 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TOMLOptions defines values needed for toml generation
+type TOMLOptions struct {
+	Type     string  `json:"type" xml:"type" fake:"{randomstring:[array,object]}"` // array or object
+	RowCount int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
+	Table    string  `json:"table" xml:"table"` // Name of the array of tables for the array type, defaults to record
+	Fields   []Field `json:"fields" xml:"fields" fake:"{fields}"`
+}
+
+// TOML generates a table or an array of tables in toml format. Null values are left out as toml has no null.
+// A nil TOMLOptions returns a randomly structured TOML.
+func TOML(to *TOMLOptions) ([]byte, error) { return tomlFunc(GlobalFaker, to) }
+
+// TOML generates a table or an array of tables in toml format. Null values are left out as toml has no null.
+// A nil TOMLOptions returns a randomly structured TOML.
+func (f *Faker) TOML(to *TOMLOptions) ([]byte, error) { return tomlFunc(f, to) }
+
+func tomlFunc(f *Faker, to *TOMLOptions) ([]byte, error) {
+	if to == nil {
+		// We didn't get a TOMLOptions, so create a new random one
+		err := f.Struct(&to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check to make sure they passed in a type
+	if to.Type != "array" && to.Type != "object" {
+		return nil, errors.New("invalid type, must be array or object")
+	}
+
+	if to.Fields == nil || len(to.Fields) <= 0 {
+		return nil, errors.New("must pass fields in order to build toml table(s)")
+	}
+
+	var buf bytes.Buffer
+	if to.Type == "object" {
+		// Object only has one row for autoincrement
+		obj, err := f.fieldObject(to.Fields, 1, 0, plainLeafValue)
+		if err != nil {
+			return nil, err
+		}
+
+		err = tomlTable(&buf, nil, obj)
+		if err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	// Make sure you set a row count
+	if to.RowCount <= 0 {
+		return nil, errors.New("must have row count")
+	}
+
+	if to.Table == "" {
+		to.Table = "record"
+	}
+
+	rows := make([]any, to.RowCount)
+	for i := range rows {
+		obj, err := f.fieldObject(to.Fields, i+1, 0, plainLeafValue)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = obj
+	}
+
+	err := tomlTable(&buf, nil, jsonOrderedKeyVal{{Key: to.Table, Value: rows}})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// tomlTable will write the key values of a table followed by its sub tables and arrays of tables,
+// as toml requires key values to come before any table header
+func tomlTable(buf *bytes.Buffer, path []string, value any) error {
+	keys, values, _ := plainEntries(value)
+
+	type table struct {
+		key   string
+		value any
+		array bool
+	}
+	var tables []table
+
+	for i, key := range keys {
+		value, err := plainValue(values[i])
+		if err != nil {
+			return err
+		}
+
+		// Toml has no null so the key is left out
+		if value == nil {
+			continue
+		}
+
+		if _, _, ok := plainEntries(value); ok && plainCollection(value) {
+			tables = append(tables, table{key: key, value: value})
+			continue
+		}
+		if tomlTableArray(value) {
+			tables = append(tables, table{key: key, value: value, array: true})
+			continue
+		}
+
+		inline, err := tomlInline(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(tomlKey(key) + " = " + inline + "\n")
+	}
+
+	for _, t := range tables {
+		tablePath := append(append([]string{}, path...), tomlKey(t.key))
+		header := strings.Join(tablePath, ".")
+
+		if !t.array {
+			tomlHeader(buf, "["+header+"]")
+			err := tomlTable(buf, tablePath, t.value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, item := range t.value.([]any) {
+			tomlHeader(buf, "[["+header+"]]")
+			err := tomlTable(buf, tablePath, item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// tomlHeader will write a table header separated from anything before it by an empty line
+func tomlHeader(buf *bytes.Buffer, header string) {
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString(header + "\n")
+}
+
+// tomlTableArray will check if a value is a non empty array where every item is an object
+func tomlTableArray(value any) bool {
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		item, err := plainValue(item)
+		if err != nil {
+			return false
+		}
+		if _, _, ok := plainEntries(item); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tomlInline will format a value on a single line, objects as inline tables
+func tomlInline(value any) (string, error) {
+	value, err := plainValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return tomlString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			fv, err := v.Float64()
+			if err != nil {
+				return "", err
+			}
+			return tomlFloat(fv, 64), nil
+		}
+		return v.String(), nil
+	case []any:
+		var parts []string
+		for _, item := range v {
+			if item == nil {
+				continue
+			}
+
+			part, err := tomlInline(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	}
+
+	if keys, values, ok := plainEntries(value); ok {
+		var parts []string
+		for i, key := range keys {
+			item, err := plainValue(values[i])
+			if err != nil {
+				return "", err
+			}
+			if item == nil {
+				continue
+			}
+
+			part, err := tomlInline(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, tomlKey(key)+" = "+part)
+		}
+		if len(parts) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// Toml integers are 64 bit signed, larger values are kept as strings
+		if rv.Uint() > math.MaxInt64 {
+			return tomlString(strconv.FormatUint(rv.Uint(), 10)), nil
+		}
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return tomlFloat(rv.Float(), rv.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("toml cannot output type %T", value)
+}
+
+// tomlFloat will format a float, including inf and nan, so it is never read as an integer
+func tomlFloat(v float64, bits int) string {
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+
+	return plainFloat(v, bits)
+}
+
+// tomlKey will return a bare key if it only has letters, numbers, dashes and underscores, otherwise it is quoted
+func tomlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return tomlString(key)
+		}
+	}
+
+	return key
+}
+
+// tomlString will return a basic string with quotes, backslashes and control characters escaped
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+func addFileTOMLLookup() {
+	AddFuncLookup("toml", Info{
+		Display:     "TOML",
+		Category:    "file",
+		Description: "Minimal configuration file format with key value pairs and tables, returns a table or an array of tables",
+		Example: `[[record]]
+first_name = "Markus"
+last_name = "Moen"
+password = "Dc0VYXjkWABx"
+
+[[record]]
+first_name = "Osborne"
+last_name = "Hilll"
+password = "XPJ9OVNbs5lm"`,
+		Output:      "[]byte",
+		ContentType: "application/toml",
+		Params: []Param{
+			{Field: "type", Display: "Type", Type: "string", Default: "object", Options: []string{"object", "array"}, Description: "Type of TOML, a table or an array of tables"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of tables in TOML array"},
+			{Field: "table", Display: "Table", Type: "string", Default: "record", Description: "Name of the array of tables"},
+			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			to := TOMLOptions{}
+
+			typ, err := info.GetString(m, "type")
+			if err != nil {
+				return nil, err
+			}
+			to.Type = typ
+
+			rowcount, err := info.GetInt(m, "rowcount")
+			if err != nil {
+				return nil, err
+			}
+			to.RowCount = rowcount
+
+			table, err := info.GetString(m, "table")
+			if err != nil {
+				return nil, err
+			}
+			to.Table = table
+
+			fieldsStr, err := info.GetStringArray(m, "fields")
+			if err != nil {
+				return nil, err
+			}
+
+			// Check to make sure fields has length
+			if len(fieldsStr) > 0 {
+				to.Fields = make([]Field, len(fieldsStr))
+
+				for i, f := range fieldsStr {
+					// Unmarshal fields string into fields array
+					err = json.Unmarshal([]byte(f), &to.Fields[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return tomlFunc(f, &to)
+		},
+	})
+}
@@ -0,0 +1,228 @@
+package gofakeit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleTOML() {
+	Seed(11)
+
+	value, err := TOML(&TOMLOptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "name", Function: "appname"},
+			{Name: "version", Function: "appversion"},
+			{Name: "server", Fields: []Field{
+				{Name: "host", Function: "domainname"},
+				{Name: "port", Function: "number", Params: MapParams{"min": {"1024"}, "max": {"9999"}}},
+			}},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output: name = "Swanthink"
+	// version = "3.5.15"
+	//
+	// [server]
+	// host = "humansolutions.org"
+	// port = 9126
+}
+
+func ExampleFaker_TOML() {
+	f := New(11)
+
+	value, err := f.TOML(&TOMLOptions{
+		Type:     "array",
+		RowCount: 2,
+		Table:    "users",
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "enabled", Function: "bool"},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output: [[users]]
+	// id = 1
+	// first_name = "Sonny"
+	// enabled = false
+	//
+	// [[users]]
+	// id = 2
+	// first_name = "Cody"
+	// enabled = true
+}
+
+func TestTOMLValues(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"hello", `"hello"`},
+		{"yes", `"yes"`},
+		{`a "quote" and \`, `"a \"quote\" and \\"`},
+		{"line\nbreak\ttab", `"line\nbreak\ttab"`},
+		{"bell\a", `"bell\u0007"`},
+		{true, "true"},
+		{42, "42"},
+		{uint64(math.MaxUint64), `"18446744073709551615"`},
+		{2.0, "2.0"},
+		{0.5, "0.5"},
+		{math.Inf(-1), "-inf"},
+		{math.NaN(), "nan"},
+		{[]any{1, nil, "a"}, `[1, "a"]`},
+		{map[string]any{"b": 1, "a key": "x", "c": nil}, `{ "a key" = "x", b = 1 }`},
+		{map[string]any{}, "{}"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "2024-01-02T03:04:05Z"},
+	}
+
+	for _, test := range tests {
+		got, err := tomlInline(test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%#v: got %s want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestTOMLNested(t *testing.T) {
+	f := New(11)
+	f.AddFuncLookup("nothing", Info{
+		Output: "string",
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			return nil, nil
+		},
+	})
+
+	value, err := f.TOML(&TOMLOptions{
+		Type:     "array",
+		RowCount: 2,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "deleted_at", Function: "nothing"},
+			{Name: "server", Fields: []Field{
+				{Name: "host", Value: "localhost"},
+				{Name: "ports", Value: []int{80, 443}},
+				{Name: "tls", Fields: []Field{{Name: "enabled", Value: true}}},
+			}},
+			{Name: "name", Value: "web"},
+			{Name: "items", Count: 2, Fields: []Field{
+				{Name: "line", Function: "autoincrement"},
+				{Name: "meta", Value: map[string]any{"x": 1}},
+			}},
+			{Name: "json", Function: "json", Params: MapParams{"type": {"object"}, "fields": {`{"name":"ratio","value":1.5}`}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := `name = "web"
+
+[record.server]
+host = "localhost"
+ports = [80, 443]
+
+[record.server.tls]
+enabled = true
+
+[[record.items]]
+line = 1
+
+[record.items.meta]
+x = 1
+
+[[record.items]]
+line = 2
+
+[record.items.meta]
+x = 1
+
+[record.json]
+ratio = 1.5
+`
+	want := "[[record]]\nid = 1\n" + row + "\n[[record]]\nid = 2\n" + row
+	if string(value) != want {
+		t.Errorf("got\n%s\nwant\n%s", value, want)
+	}
+}
+
+func TestTOMLErrors(t *testing.T) {
+	tests := map[string]*TOMLOptions{
+		"invalid type": {Type: "list", Fields: []Field{{Name: "a", Function: "city"}}},
+		"no fields":    {Type: "object"},
+		"no row count": {Type: "array", Fields: []Field{{Name: "a", Function: "city"}}},
+		"bad function": {Type: "object", Fields: []Field{{Name: "a", Function: "notafunction"}}},
+	}
+
+	for name, to := range tests {
+		if _, err := TOML(to); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestTOMLNil(t *testing.T) {
+	value, err := New(11).TOML(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(value) == 0 {
+		t.Error("expected random toml")
+	}
+}
+
+func TestTOMLLookup(t *testing.T) {
+	info := GetFuncLookup("toml")
+
+	m := MapParams{
+		"type":     {"array"},
+		"rowcount": {"3"},
+		"table":    {"users"},
+		"fields": {
+			`{"name":"id","function":"autoincrement"}`,
+			`{"name":"first_name","function":"firstname"}`,
+		},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(string(value.([]byte)), "[[users]]\n") != 3 {
+		t.Errorf("expected 3 tables, got %s", value)
+	}
+}
+
+func BenchmarkTOML(b *testing.B) {
+	f := New(11)
+	to := &TOMLOptions{
+		Type:     "array",
+		RowCount: 10,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "address", Fields: []Field{{Name: "city", Function: "city"}}},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		f.TOML(to)
+	}
+}
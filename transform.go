@@ -0,0 +1,165 @@
+package gofakeit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TransformFunc modifies a generated value within a generate placeholder.
+// Args are the comma separated values after the transform name.
+// Ex: {sentence:5|truncate:20} calls truncate with args ["20"]
+type TransformFunc func(f *Faker, value string, args []string) (string, error)
+
+// Transforms is the map of all available generate transforms
+var Transforms map[string]TransformFunc
+var lockTransforms sync.RWMutex
+
+func init() { initTransforms() }
+
+// initTransforms will add all the default transforms
+func initTransforms() {
+	AddTransform("lower", func(f *Faker, value string, args []string) (string, error) {
+		return strings.ToLower(value), nil
+	})
+
+	AddTransform("upper", func(f *Faker, value string, args []string) (string, error) {
+		return strings.ToUpper(value), nil
+	})
+
+	AddTransform("title", func(f *Faker, value string, args []string) (string, error) {
+		return title(value), nil
+	})
+
+	AddTransform("trim", func(f *Faker, value string, args []string) (string, error) {
+		return strings.TrimSpace(value), nil
+	})
+
+	AddTransform("slug", func(f *Faker, value string, args []string) (string, error) {
+		return slugify(value), nil
+	})
+
+	AddTransform("truncate", func(f *Faker, value string, args []string) (string, error) {
+		if len(args) < 1 {
+			return "", errors.New("truncate transform requires a length")
+		}
+		length, err := strconv.Atoi(args[0])
+		if err != nil || length < 0 {
+			return "", fmt.Errorf("truncate transform length %s must be a positive number", args[0])
+		}
+
+		if utf8.RuneCountInString(value) <= length {
+			return value, nil
+		}
+
+		return string([]rune(value)[:length]), nil
+	})
+
+	AddTransform("pad", func(f *Faker, value string, args []string) (string, error) {
+		if len(args) < 1 {
+			return "", errors.New("pad transform requires a length")
+		}
+		length, err := strconv.Atoi(args[0])
+		if err != nil || length < 0 {
+			return "", fmt.Errorf("pad transform length %s must be a positive number", args[0])
+		}
+
+		// Default to zero padding
+		padChar := "0"
+		if len(args) >= 2 && args[1] != "" {
+			padChar = args[1]
+		}
+
+		count := length - utf8.RuneCountInString(value)
+		if count <= 0 {
+			return value, nil
+		}
+
+		return strings.Repeat(padChar, count) + value, nil
+	})
+
+	AddTransform("replace", func(f *Faker, value string, args []string) (string, error) {
+		if len(args) < 2 {
+			return "", errors.New("replace transform requires an old and new value")
+		}
+
+		return strings.ReplaceAll(value, args[0], args[1]), nil
+	})
+}
+
+// AddTransform adds a transform that can be used within generate placeholders
+func AddTransform(name string, fn TransformFunc) {
+	lockTransforms.Lock()
+	if Transforms == nil {
+		Transforms = make(map[string]TransformFunc)
+	}
+	Transforms[name] = fn
+	lockTransforms.Unlock()
+}
+
+// GetTransform will return the transform for the given name or nil if it doesnt exist
+func GetTransform(name string) TransformFunc {
+	lockTransforms.RLock()
+	defer lockTransforms.RUnlock()
+
+	return Transforms[name]
+}
+
+// RemoveTransform will remove a transform
+func RemoveTransform(name string) {
+	lockTransforms.Lock()
+	delete(Transforms, name)
+	lockTransforms.Unlock()
+}
+
+// applyTransforms will run the value through each transform in order
+func applyTransforms(f *Faker, value string, transforms []string) (string, error) {
+	for _, transform := range transforms {
+		name, params, _ := strings.Cut(transform, ":")
+		fn := GetTransform(name)
+		if fn == nil {
+			return "", fmt.Errorf("transform %s does not exist", name)
+		}
+
+		var args []string
+		if params != "" {
+			var err error
+			args, err = funcLookupSplit(params)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		var err error
+		value, err = fn(f, value, args)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return value, nil
+}
+
+// slugify will lowercase a string and join any alphanumeric runs with dashes
+func slugify(str string) string {
+	var sb strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(str) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if sep && sb.Len() > 0 {
+				sb.WriteRune(dash)
+			}
+			sb.WriteRune(r)
+			sep = false
+			continue
+		}
+
+		sep = true
+	}
+
+	return sb.String()
+}
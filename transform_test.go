@@ -0,0 +1,107 @@
+package gofakeit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleAddTransform() {
+	f := New(11)
+
+	AddTransform("reverse", func(f *Faker, value string, args []string) (string, error) {
+		runes := []rune(value)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	})
+	defer RemoveTransform("reverse")
+
+	value, _ := f.Generate("{firstname|reverse|lower}")
+	fmt.Println(value)
+
+	// Output: ynnos
+}
+
+func TestGenerateTransforms(t *testing.T) {
+	f := New(11)
+
+	tests := []struct {
+		pattern string
+		check   func(string) bool
+	}{
+		{"{firstname|lower}", func(s string) bool { return s == strings.ToLower(s) && s != "" }},
+		{"{uuid|upper}", func(s string) bool { return s == strings.ToUpper(s) && len(s) == 36 }},
+		{"{sentence:10|truncate:20}", func(s string) bool { return len(s) == 20 }},
+		{"{company|slug}", func(s string) bool { return s == slugify(s) && !strings.Contains(s, " ") }},
+		{"{number:1,100|pad:5}", func(s string) bool { return len(s) == 5 && strings.HasPrefix(s, "00") }},
+		{"{number:1,9|pad:3,x}", func(s string) bool { return strings.HasPrefix(s, "xx") }},
+		{"{firstname|upper|replace:A,@}", func(s string) bool { return !strings.Contains(s, "A") }},
+		{"{regex:(ab|bc)}", func(s string) bool { return s == "ab" || s == "bc" }},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			value, err := f.Generate(tt.pattern)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.pattern, err)
+			}
+			if !tt.check(value) {
+				t.Fatalf("%s produced unexpected value %q", tt.pattern, value)
+			}
+		}
+	}
+}
+
+func TestGenerateTransformsError(t *testing.T) {
+	if _, err := Generate("{sentence:5|truncate}"); err == nil {
+		t.Fatal("expected error for truncate without a length")
+	}
+	if _, err := Generate("{sentence:5|truncate:abc}"); err == nil {
+		t.Fatal("expected error for truncate with an invalid length")
+	}
+}
+
+func TestStructTransforms(t *testing.T) {
+	var s struct {
+		Slug   string `fake:"{company|slug}"`
+		Code   string `fake:"{lettern:3|upper}"`
+		Number int    `fake:"{number:1,100|pad:5}"`
+	}
+
+	if err := New(11).Struct(&s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Slug != slugify(s.Slug) {
+		t.Errorf("expected slug, got %s", s.Slug)
+	}
+	if s.Code != strings.ToUpper(s.Code) || len(s.Code) != 3 {
+		t.Errorf("expected upper case code, got %s", s.Code)
+	}
+	if s.Number < 1 || s.Number > 100 {
+		t.Errorf("expected number between 1 and 100, got %d", s.Number)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello World":         "hello-world",
+		"  Acme, Inc.  ":      "acme-inc",
+		"already-a-slug":      "already-a-slug",
+		"Ünïcode Çompany 123": "ünïcode-çompany-123",
+	}
+
+	for in, want := range tests {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func BenchmarkGenerateTransforms(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Generate("{firstname|lower}.{lastname|lower}@{domainname}")
+	}
+}
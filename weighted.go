@@ -81,8 +81,8 @@ func addWeightedLookup() {
 		Example:     "[hello, 2, 6.9],[1, 2, 3] => 6.9",
 		Output:      "any",
 		Params: []Param{
-			{Field: "options", Display: "Options", Type: "[]string", Description: "Array of any values"},
-			{Field: "weights", Display: "Weights", Type: "[]float", Description: "Array of weights"},
+			{Field: "options", Display: "Options", Type: "[]string", MinItems: 1, Description: "Array of any values"},
+			{Field: "weights", Display: "Weights", Type: "[]float", MinItems: 1, Min: "0", Description: "Array of weights"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			options, err := info.GetStringArray(m, "options")
@@ -127,7 +127,7 @@ func addWordSentenceLookup() {
 		Example:     "Interpret context record river mind.",
 		Output:      "string",
 		Params: []Param{
-			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Description: "Number of words in a sentence"},
+			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Min: "1", Max: "50", Description: "Number of words in a sentence"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			wordCount, err := info.GetInt(m, "wordcount")
@@ -149,9 +149,9 @@ func addWordSentenceLookup() {
 		Example:     "Interpret context record river mind press self should compare property outcome divide. Combine approach sustain consult discover explanation direct address church husband seek army. Begin own act welfare replace press suspect stay link place manchester specialist. Arrive price satisfy sign force application hair train provide basis right pay. Close mark teacher strengthen information attempt head touch aim iron tv take.",
 		Output:      "string",
 		Params: []Param{
-			{Field: "paragraphcount", Display: "Paragraph Count", Type: "int", Default: "2", Description: "Number of paragraphs"},
-			{Field: "sentencecount", Display: "Sentence Count", Type: "int", Default: "2", Description: "Number of sentences in a paragraph"},
-			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Description: "Number of words in a sentence"},
+			{Field: "paragraphcount", Display: "Paragraph Count", Type: "int", Default: "2", Min: "1", Max: "20", Description: "Number of paragraphs"},
+			{Field: "sentencecount", Display: "Sentence Count", Type: "int", Default: "2", Min: "1", Max: "20", Description: "Number of sentences in a paragraph"},
+			{Field: "wordcount", Display: "Word Count", Type: "int", Default: "5", Min: "1", Max: "50", Description: "Number of words in a sentence"},
 			{Field: "paragraphseparator", Display: "Paragraph Separator", Type: "string", Default: "<br />", Description: "String value to add between paragraphs"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
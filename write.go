@@ -0,0 +1,5 @@
+package gofakeit
+
+// WriteProgress is called by the streaming writers after each row is written
+// with the number of rows written so far and the total number of rows
+type WriteProgress func(written int, total int)
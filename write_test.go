@@ -0,0 +1,110 @@
+package gofakeit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+// errWriter fails every write, recording the rows written when it first failed
+type errWriter struct {
+	written  *int
+	failedAt int
+	failed   bool
+}
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if !w.failed {
+		w.failed = true
+		w.failedAt = *w.written
+	}
+
+	return 0, errors.New("write failed")
+}
+
+func writeTests(f *Faker, rows int) map[string]func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+	fields := []Field{
+		{Name: "id", Function: "autoincrement"},
+		{Name: "name", Function: "name"},
+	}
+
+	return map[string]func(ctx context.Context, w io.Writer, progress WriteProgress) error{
+		"csv": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteCSV(ctx, w, &CSVOptions{RowCount: rows, Fields: fields}, progress)
+		},
+		"ndjson": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteNDJSON(ctx, w, &JSONOptions{RowCount: rows, Fields: fields}, progress)
+		},
+		"xml": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteXML(ctx, w, &XMLOptions{Type: "array", RowCount: rows, Fields: fields}, progress)
+		},
+		"sql": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteSQL(ctx, w, &SQLOptions{Table: "people", Count: rows, Fields: fields}, progress)
+		},
+		"logline": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteLogLines(ctx, w, &LogLineOptions{Format: "combined", LineCount: rows}, progress)
+		},
+		"logrecord": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteLogRecords(ctx, w, &LogRecordOptions{Format: "rfc5424", LineCount: rows}, progress)
+		},
+		"logrecord json": func(ctx context.Context, w io.Writer, progress WriteProgress) error {
+			return f.WriteLogRecords(ctx, w, &LogRecordOptions{Format: "json", LineCount: rows}, progress)
+		},
+	}
+}
+
+func TestWriteProgress(t *testing.T) {
+	for name, write := range writeTests(New(11), 100) {
+		calls := 0
+		err := write(context.Background(), io.Discard, func(written int, total int) {
+			calls++
+			if written != calls || total != 100 {
+				t.Errorf("%s: unexpected progress %d of %d", name, written, total)
+			}
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if calls != 100 {
+			t.Errorf("%s: expected 100 progress calls, got %d", name, calls)
+		}
+	}
+}
+
+func TestWriteCancel(t *testing.T) {
+	for name, write := range writeTests(New(11), 100) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		written := 0
+		err := write(ctx, io.Discard, func(w int, total int) {
+			written = w
+			if w == 10 {
+				cancel()
+			}
+		})
+		cancel()
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context canceled error, got %v", name, err)
+		}
+		if written != 10 {
+			t.Errorf("%s: expected writing to stop after 10 rows, got %d", name, written)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	// Enough rows to fill the write buffer before the end
+	for name, write := range writeTests(New(11), 1000) {
+		written := 0
+		w := &errWriter{written: &written}
+		err := write(context.Background(), w, func(n int, total int) { written = n })
+		if err == nil {
+			t.Errorf("%s: expected writer error", name)
+		}
+		if !w.failed || written != w.failedAt || written >= 1000 {
+			t.Errorf("%s: expected writing to stop at row %d, got %d", name, w.failedAt+1, written)
+		}
+	}
+}
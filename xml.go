@@ -2,10 +2,15 @@ package gofakeit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // XMLOptions defines values needed for json generation
@@ -16,11 +21,10 @@ type XMLOptions struct {
 	RowCount      int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
 	Indent        bool    `json:"indent" xml:"indent"`
 	Fields        []Field `json:"fields" xml:"fields" fake:"{fields}"`
-}
 
-type xmlArray struct {
-	XMLName xml.Name
-	Array   []xmlMap
+	Namespaces  map[string]string `json:"namespaces" xml:"-" fake:"skip"`            // Prefix to url declared on the root element, an empty prefix is the default namespace
+	Declaration bool              `json:"declaration" xml:"declaration" fake:"skip"` // Start with <?xml version="1.0" encoding="UTF-8"?>
+	DocType     string            `json:"doctype" xml:"doctype" fake:"skip"`         // Output a <!DOCTYPE> after the declaration. Ex: html
 }
 
 type xmlMap struct {
@@ -34,6 +38,60 @@ type xmlEntry struct {
 	Value   any `xml:",chardata"`
 }
 
+type xmlCDATA struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Value   string     `xml:",cdata"`
+}
+
+// xmlElement is an element generated from fields, keeping its
+// attributes, text and children in the order of the fields
+type xmlElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr
+	Text     string
+	CDATA    bool
+	Children []xmlChild
+}
+
+// xmlChild is either a nested xmlElement or a function value to encode
+type xmlChild struct {
+	name  string
+	value any
+}
+
+func (x xmlElement) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if x.CDATA && len(x.Children) == 0 {
+		return e.Encode(xmlCDATA{XMLName: x.XMLName, Attrs: x.Attrs, Value: x.Text})
+	}
+
+	start = xml.StartElement{Name: x.XMLName, Attr: x.Attrs}
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	if x.Text != "" {
+		err = e.EncodeToken(xml.CharData(x.Text))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, child := range x.Children {
+		if el, ok := child.value.(xmlElement); ok {
+			err = e.Encode(el)
+		} else {
+			err = xmlEncodeValue(e, child.name, child.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
 func (m xmlMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if len(m.Map) == 0 {
 		return nil
@@ -55,8 +113,6 @@ func (m xmlMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 }
 
 func xmlMapLoop(e *xml.Encoder, m *xmlMap) error {
-	var err error
-
 	// Check if xmlmap has key order if not create it
 	// Get key order by order of fields array
 	if m.KeyOrder == nil {
@@ -67,65 +123,191 @@ func xmlMapLoop(e *xml.Encoder, m *xmlMap) error {
 	}
 
 	for _, key := range m.KeyOrder {
-		v := reflect.ValueOf(m.Map[key])
-
-		// Always get underlyning Value of value
-		if v.Kind() == reflect.Ptr {
-			v = reflect.Indirect(v)
+		err := xmlEncodeValue(e, key, m.Map[key])
+		if err != nil {
+			return err
 		}
+	}
 
-		switch v.Kind() {
-		case reflect.Bool,
-			reflect.String,
-			reflect.Int, reflect.Int8, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint32, reflect.Uint64,
-			reflect.Float32, reflect.Float64:
-			err = e.Encode(xmlEntry{XMLName: xml.Name{Local: key}, Value: m.Map[key]})
-			if err != nil {
-				return err
-			}
-		case reflect.Slice:
-			e.EncodeToken(xml.StartElement{Name: xml.Name{Local: key}})
-			for i := 0; i < v.Len(); i++ {
-				err = e.Encode(xmlEntry{XMLName: xml.Name{Local: "value"}, Value: v.Index(i).String()})
-				if err != nil {
-					return err
-				}
-			}
-			e.EncodeToken(xml.EndElement{Name: xml.Name{Local: key}})
-		case reflect.Map:
-			err = e.Encode(xmlMap{
-				XMLName: xml.Name{Local: key},
-				Map:     m.Map[key].(map[string]any),
-			})
-			if err != nil {
-				return err
-			}
-		case reflect.Struct:
-			// Convert struct to map[string]any
-			// So we can rewrap element
-			var inInterface map[string]any
-			inrec, _ := json.Marshal(m.Map[key])
-			json.Unmarshal(inrec, &inInterface)
-
-			err = e.Encode(xmlMap{
-				XMLName: xml.Name{Local: key},
-				Map:     inInterface,
-			})
-			if err != nil {
-				return err
-			}
-		default:
-			err = e.Encode(m.Map[key])
+	return nil
+}
+
+// xmlEncodeValue will encode a function value as an element named key
+func xmlEncodeValue(e *xml.Encoder, key string, value any) error {
+	var err error
+	v := reflect.ValueOf(value)
+
+	// Always get underlyning Value of value
+	if v.Kind() == reflect.Ptr {
+		v = reflect.Indirect(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool,
+		reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		err = e.Encode(xmlEntry{XMLName: xml.Name{Local: key}, Value: value})
+		if err != nil {
+			return err
+		}
+	case reflect.Slice:
+		e.EncodeToken(xml.StartElement{Name: xml.Name{Local: key}})
+		for i := 0; i < v.Len(); i++ {
+			err = e.Encode(xmlEntry{XMLName: xml.Name{Local: "value"}, Value: v.Index(i).String()})
 			if err != nil {
 				return err
 			}
 		}
+		e.EncodeToken(xml.EndElement{Name: xml.Name{Local: key}})
+	case reflect.Map:
+		err = e.Encode(xmlMap{
+			XMLName: xml.Name{Local: key},
+			Map:     value.(map[string]any),
+		})
+		if err != nil {
+			return err
+		}
+	case reflect.Struct:
+		// Convert struct to map[string]any
+		// So we can rewrap element
+		var inInterface map[string]any
+		inrec, _ := json.Marshal(value)
+		json.Unmarshal(inrec, &inInterface)
+
+		err = e.Encode(xmlMap{
+			XMLName: xml.Name{Local: key},
+			Map:     inInterface,
+		})
+		if err != nil {
+			return err
+		}
+	default:
+		err = e.Encode(value)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// xmlFieldElement will convert generated fields into an element, applying each fields xml flags
+func xmlFieldElement(f *Faker, name string, fields []Field, obj jsonOrderedKeyVal) xmlElement {
+	el := xmlElement{XMLName: xml.Name{Local: name}}
+
+	for i, kv := range obj {
+		field := &fields[i]
+		attr, text, cdata, repeat := xmlFieldFlags(field)
+
+		switch {
+		case attr:
+			el.Attrs = append(el.Attrs, xml.Attr{Name: xml.Name{Local: field.Name}, Value: fmt.Sprintf("%v", kv.Value)})
+			continue
+		case text:
+			el.Text = fmt.Sprintf("%v", kv.Value)
+			el.CDATA = cdata
+			continue
+		}
+
+		items, isArray := kv.Value.([]any)
+		if !isArray || (field.Count == 0 && field.CountMax == 0) {
+			el.Children = append(el.Children, xmlChild{name: field.Name, value: xmlFieldItem(f, field.Name, field, kv.Value)})
+			continue
+		}
+
+		// Repeated arrays output each item as its own element, otherwise items are wrapped in value elements
+		if repeat {
+			for _, item := range items {
+				el.Children = append(el.Children, xmlChild{name: field.Name, value: xmlFieldItem(f, field.Name, field, item)})
+			}
+			continue
+		}
+
+		list := xmlElement{XMLName: xml.Name{Local: field.Name}}
+		xmlNamespace(&list, field)
+		for _, item := range items {
+			list.Children = append(list.Children, xmlChild{name: "value", value: xmlFieldItem(f, "value", field, item)})
+		}
+		el.Children = append(el.Children, xmlChild{name: field.Name, value: list})
+	}
+
+	return el
+}
+
+// xmlFieldItem will convert a single field value into an element,
+// leaving plain function values as is to be encoded by xmlEncodeValue
+func xmlFieldItem(f *Faker, name string, field *Field, value any) any {
+	if obj, ok := value.(jsonOrderedKeyVal); ok {
+		children, _ := f.fieldChildren(field)
+		el := xmlFieldElement(f, name, children, obj)
+		xmlNamespace(&el, field)
+		return el
+	}
+
+	_, _, cdata, _ := xmlFieldFlags(field)
+	if !cdata && field.Namespace == "" {
+		return value
+	}
+
+	el := xmlElement{XMLName: xml.Name{Local: name}, Text: fmt.Sprintf("%v", value), CDATA: cdata}
+	xmlNamespace(&el, field)
+	return el
+}
+
+// xmlFieldFlags will parse the comma separated xml flags of a field. Ex: attr or cdata,repeat
+func xmlFieldFlags(field *Field) (attr bool, text bool, cdata bool, repeat bool) {
+	for _, flag := range strings.Split(field.XML, ",") {
+		switch strings.TrimSpace(flag) {
+		case "attr":
+			attr = true
+		case "text":
+			text = true
+		case "cdata":
+			cdata = true
+		case "repeat":
+			repeat = true
+		}
+	}
+
+	return attr, text, cdata, repeat
+}
+
+// xmlNamespace will declare the field namespace on its element,
+// using the prefix of the field name if it has one. Ex: g:price
+func xmlNamespace(el *xmlElement, field *Field) {
+	if field.Namespace == "" {
+		return
+	}
+
+	attr := "xmlns"
+	if prefix, _, ok := strings.Cut(field.Name, ":"); ok {
+		attr += ":" + prefix
+	}
+	el.Attrs = append(el.Attrs, xml.Attr{Name: xml.Name{Local: attr}, Value: field.Namespace})
+}
+
+// xmlNamespaces will return the xmlns attributes for the options namespaces sorted by prefix
+func xmlNamespaces(namespaces map[string]string) []xml.Attr {
+	prefixes := make([]string, 0, len(namespaces))
+	for prefix := range namespaces {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	attrs := make([]xml.Attr, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		name := "xmlns"
+		if prefix != "" {
+			name += ":" + prefix
+		}
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: name}, Value: namespaces[prefix]})
+	}
+
+	return attrs
+}
+
 // XML generates an object or an array of objects in json format
 // A nil XMLOptions returns a randomly structured XML.
 func XML(xo *XMLOptions) ([]byte, error) { return xmlFunc(GlobalFaker, xo) }
@@ -135,27 +317,51 @@ func XML(xo *XMLOptions) ([]byte, error) { return xmlFunc(GlobalFaker, xo) }
 func (f *Faker) XML(xo *XMLOptions) ([]byte, error) { return xmlFunc(f, xo) }
 
 func xmlFunc(f *Faker, xo *XMLOptions) ([]byte, error) {
+	var b bytes.Buffer
+	err := writeXML(context.Background(), f, &b, xo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// WriteXML streams xml records to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each record.
+// A nil XMLOptions writes a randomly structured XML.
+func WriteXML(ctx context.Context, w io.Writer, xo *XMLOptions, progress WriteProgress) error {
+	return writeXML(ctx, GlobalFaker, w, xo, progress)
+}
+
+// WriteXML streams xml records to w as they are generated, keeping memory use constant.
+// Writing stops when ctx is cancelled and progress, if not nil, is called after each record.
+// A nil XMLOptions writes a randomly structured XML.
+func (f *Faker) WriteXML(ctx context.Context, w io.Writer, xo *XMLOptions, progress WriteProgress) error {
+	return writeXML(ctx, f, w, xo, progress)
+}
+
+func writeXML(ctx context.Context, f *Faker, w io.Writer, xo *XMLOptions, progress WriteProgress) error {
 	if xo == nil {
 		// We didn't get a XMLOptions, so create a new random one
 		err := f.Struct(&xo)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// Check to make sure they passed in a type
 	if xo.Type != "single" && xo.Type != "array" {
-		return nil, errors.New("invalid type, must be array or object")
+		return errors.New("invalid type, must be array or object")
 	}
 
 	// Check fields length
 	if xo.Fields == nil || len(xo.Fields) <= 0 {
-		return nil, errors.New("must pass fields in order to build json object(s)")
+		return errors.New("must pass fields in order to build json object(s)")
 	}
 
 	// Check root element string
 	if xo.RootElement == "" {
-		xo.RecordElement = "xml"
+		xo.RootElement = "xml"
 	}
 
 	// Check record element string
@@ -163,106 +369,111 @@ func xmlFunc(f *Faker, xo *XMLOptions) ([]byte, error) {
 		xo.RecordElement = "record"
 	}
 
-	// Get key order by order of fields array
-	keyOrder := make([]string, 0, len(xo.Fields))
-	for _, f := range xo.Fields {
-		keyOrder = append(keyOrder, f.Name)
-	}
-
 	if xo.Type == "single" {
-		v := xmlMap{
-			XMLName:  xml.Name{Local: xo.RootElement},
-			KeyOrder: keyOrder,
-			Map:      make(map[string]any),
+		err := xmlWriteHeader(w, xo)
+		if err != nil {
+			return err
 		}
 
-		// Loop through fields and add to them to map[string]any
-		for _, field := range xo.Fields {
-			// Get function info
-			funcInfo := GetFuncLookup(field.Function)
-			if funcInfo == nil {
-				return nil, errors.New("invalid function, " + field.Function + " does not exist")
-			}
-
-			value, err := funcInfo.Generate(f, &field.Params, funcInfo)
-			if err != nil {
-				return nil, err
-			}
-
-			v.Map[field.Name] = value
+		obj, err := f.fieldObject(xo.Fields, 1, 0, nil)
+		if err != nil {
+			return err
 		}
 
-		// Marshal into bytes
-		var b bytes.Buffer
-		x := xml.NewEncoder(&b)
+		x := xml.NewEncoder(w)
 		if xo.Indent {
 			x.Indent("", "    ")
 		}
-		err := x.Encode(v)
+
+		el := xmlFieldElement(f, xo.RootElement, xo.Fields, obj)
+		el.Attrs = append(xmlNamespaces(xo.Namespaces), el.Attrs...)
+		err = x.Encode(el)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		return b.Bytes(), nil
+		if progress != nil {
+			progress(1, 1)
+		}
+
+		return nil
 	}
 
-	if xo.Type == "array" {
-		// Make sure you set a row count
-		if xo.RowCount <= 0 {
-			return nil, errors.New("must have row count")
-		}
+	// Make sure you set a row count
+	if xo.RowCount <= 0 {
+		return errors.New("must have row count")
+	}
 
-		xa := xmlArray{
-			XMLName: xml.Name{Local: xo.RootElement},
-			Array:   make([]xmlMap, xo.RowCount),
-		}
+	return xmlWriteRows(ctx, f, w, xo, func(i int) (jsonOrderedKeyVal, error) {
+		return f.fieldObject(xo.Fields, i, 0, nil)
+	}, progress)
+}
 
-		for i := 1; i <= int(xo.RowCount); i++ {
-			v := xmlMap{
-				XMLName:  xml.Name{Local: xo.RecordElement},
-				KeyOrder: keyOrder,
-				Map:      make(map[string]any),
-			}
+// xmlWriteHeader will write the declaration and doctype, if set, each on their own line
+func xmlWriteHeader(w io.Writer, xo *XMLOptions) error {
+	if xo.Declaration {
+		_, err := io.WriteString(w, xml.Header)
+		if err != nil {
+			return err
+		}
+	}
+	if xo.DocType != "" {
+		_, err := io.WriteString(w, "<!DOCTYPE "+xo.DocType+">\n")
+		if err != nil {
+			return err
+		}
+	}
 
-			// Loop through fields and add to them to map[string]any
-			for _, field := range xo.Fields {
-				if field.Function == "autoincrement" {
-					v.Map[field.Name] = i
-					continue
-				}
+	return nil
+}
 
-				// Get function info
-				funcInfo := GetFuncLookup(field.Function)
-				if funcInfo == nil {
-					return nil, errors.New("invalid function, " + field.Function + " does not exist")
-				}
+// xmlWriteRows will write the root element containing xo.RowCount record elements
+// with the values of each record returned by record, numbered from 1
+func xmlWriteRows(ctx context.Context, f *Faker, w io.Writer, xo *XMLOptions, record func(i int) (jsonOrderedKeyVal, error), progress WriteProgress) error {
+	err := xmlWriteHeader(w, xo)
+	if err != nil {
+		return err
+	}
 
-				value, err := funcInfo.Generate(f, &field.Params, funcInfo)
-				if err != nil {
-					return nil, err
-				}
+	x := xml.NewEncoder(w)
+	if xo.Indent {
+		x.Indent("", "    ")
+	}
 
-				v.Map[field.Name] = value
-			}
+	root := xml.StartElement{Name: xml.Name{Local: xo.RootElement}, Attr: xmlNamespaces(xo.Namespaces)}
+	err = x.EncodeToken(root)
+	if err != nil {
+		return err
+	}
 
-			xa.Array = append(xa.Array, v)
+	for i := 1; i <= xo.RowCount; i++ {
+		if err := ctx.Err(); err != nil {
+			x.Flush()
+			return err
 		}
 
-		// Marshal into bytes
-		var b bytes.Buffer
-		x := xml.NewEncoder(&b)
-		if xo.Indent {
-			x.Indent("", "    ")
+		obj, err := record(i)
+		if err != nil {
+			return err
 		}
-		err := x.Encode(xa)
+
+		// Encode flushes each record to the writer
+		err = x.Encode(xmlFieldElement(f, xo.RecordElement, xo.Fields, obj))
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		return b.Bytes(), nil
+		if progress != nil {
+			progress(i, xo.RowCount)
+		}
+	}
+
+	err = x.EncodeToken(root.End())
+	if err != nil {
+		return err
 	}
 
-	return nil, errors.New("invalid type, must be array or object")
+	return x.Flush()
 }
 
 func addFileXMLLookup() {
@@ -288,9 +499,12 @@ func addFileXMLLookup() {
 			{Field: "type", Display: "Type", Type: "string", Default: "single", Options: []string{"single", "array"}, Description: "Type of XML, single or array"},
 			{Field: "rootelement", Display: "Root Element", Type: "string", Default: "xml", Description: "Root element wrapper name"},
 			{Field: "recordelement", Display: "Record Element", Type: "string", Default: "record", Description: "Record element for each record row"},
-			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Description: "Number of rows in JSON array"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of rows in JSON array"},
 			{Field: "indent", Display: "Indent", Type: "bool", Default: "false", Description: "Whether or not to add indents and newlines"},
 			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
+			{Field: "namespaces", Display: "Namespaces", Type: "[]string", Optional: true, Description: "Namespaces declared on the root element as prefix=url, or just url for the default namespace"},
+			{Field: "declaration", Display: "Declaration", Type: "bool", Default: "false", Description: "Whether or not to start with an xml declaration"},
+			{Field: "doctype", Display: "DOCTYPE", Type: "string", Optional: true, Description: "DOCTYPE to output after the declaration"},
 		},
 		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
 			xo := XMLOptions{}
@@ -330,6 +544,26 @@ func addFileXMLLookup() {
 			}
 			xo.Indent = indent
 
+			declaration, err := info.GetBool(m, "declaration")
+			if err != nil {
+				return nil, err
+			}
+			xo.Declaration = declaration
+
+			xo.DocType, _ = info.GetString(m, "doctype")
+
+			namespaces, _ := info.GetStringArray(m, "namespaces")
+			if len(namespaces) > 0 {
+				xo.Namespaces = make(map[string]string, len(namespaces))
+				for _, ns := range namespaces {
+					prefix, url, ok := strings.Cut(ns, "=")
+					if !ok || strings.ContainsAny(prefix, ":/") {
+						prefix, url = "", ns
+					}
+					xo.Namespaces[prefix] = url
+				}
+			}
+
 			// Check to make sure fields has length
 			if len(fieldsStr) > 0 {
 				xo.Fields = make([]Field, len(fieldsStr))
@@ -1,7 +1,12 @@
 package gofakeit
 
 import (
+	"bytes"
+	"context"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 )
 
@@ -266,3 +271,199 @@ func BenchmarkXMLLookup100(b *testing.B) {
 		}
 	}
 }
+
+func TestWriteXML(t *testing.T) {
+	for _, typ := range []string{"single", "array"} {
+		xo := &XMLOptions{
+			Type:     typ,
+			RowCount: 20,
+			Indent:   true,
+			Fields: []Field{
+				{Name: "id", Function: "autoincrement"},
+				{Name: "name", Function: "name"},
+				{Name: "tags", Function: "word", Count: 2},
+			},
+		}
+
+		// Streaming output matches the in memory output
+		want, err := New(11).XML(xo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var b bytes.Buffer
+		if err := New(11).WriteXML(context.Background(), &b, xo, nil); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != string(want) {
+			t.Errorf("%s: expected streamed xml to match XML\n%s\n%s", typ, b.String(), want)
+		}
+	}
+}
+
+func ExampleXML_rss() {
+	f := New(11)
+
+	value, err := f.XML(&XMLOptions{
+		Type:        "single",
+		RootElement: "rss",
+		Indent:      true,
+		Declaration: true,
+		Namespaces:  map[string]string{"g": "http://base.google.com/ns/1.0"},
+		Fields: []Field{
+			{Name: "version", Value: "2.0", XML: "attr"},
+			{Name: "channel", Fields: []Field{
+				{Name: "title", Function: "company"},
+				{Name: "item", Count: 2, XML: "repeat", Fields: []Field{
+					{Name: "id", Function: "autoincrement", XML: "attr"},
+					{Name: "title", Function: "productname"},
+					{Name: "description", Function: "{sentence:3} <b>{productfeature}</b>", XML: "cdata"},
+					{Name: "g:price", Function: "price"},
+				}},
+			}},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <rss xmlns:g="http://base.google.com/ns/1.0" version="2.0">
+	//     <channel>
+	//         <title>TransparaGov</title>
+	//         <item id="1">
+	//             <title>Mixer Smart Touchscreen</title>
+	//             <description><![CDATA[Selfishly galaxy they. <b>smart</b>]]></description>
+	//             <g:price>210.02</g:price>
+	//         </item>
+	//         <item id="2">
+	//             <title>Shift Sharp Scale</title>
+	//             <description><![CDATA[Lastly that someone. <b>energy-efficient</b>]]></description>
+	//             <g:price>505.81</g:price>
+	//         </item>
+	//     </channel>
+	// </rss>
+}
+
+func TestXMLSitemap(t *testing.T) {
+	value, err := New(11).XML(&XMLOptions{
+		Type:          "array",
+		RootElement:   "urlset",
+		RecordElement: "url",
+		RowCount:      3,
+		Declaration:   true,
+		Namespaces:    map[string]string{"": "http://www.sitemaps.org/schemas/sitemap/0.9"},
+		Fields: []Field{
+			{Name: "loc", Function: "url"},
+			{Name: "changefreq", Function: "{daily|weekly|monthly}"},
+			{Name: "priority", Function: "{0.5|0.8|1.0}"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str := string(value)
+	if !strings.HasPrefix(str, xml.Header+`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>`) {
+		t.Errorf("unexpected sitemap %s", str)
+	}
+
+	var urlset struct {
+		URLs []struct {
+			Loc        string `xml:"loc"`
+			ChangeFreq string `xml:"changefreq"`
+		} `xml:"url"`
+	}
+	if err := xml.Unmarshal(value, &urlset); err != nil {
+		t.Fatal(err)
+	}
+	if len(urlset.URLs) != 3 || urlset.URLs[0].Loc == "" || urlset.URLs[0].ChangeFreq == "" {
+		t.Errorf("unexpected urls %+v", urlset.URLs)
+	}
+}
+
+func TestXMLAttributes(t *testing.T) {
+	value, err := New(11).XML(&XMLOptions{
+		Type:        "single",
+		RootElement: "soap:Envelope",
+		DocType:     "soap",
+		Namespaces:  map[string]string{"soap": "http://www.w3.org/2003/05/soap-envelope"},
+		Fields: []Field{
+			{Name: "soap:Body", Fields: []Field{
+				{Name: "m:GetUser", Namespace: "http://example.com/users", Fields: []Field{
+					{Name: "m:id", Function: "uuid"},
+				}},
+			}},
+			{Name: "link", Fields: []Field{
+				{Name: "rel", Value: "alternate", XML: "attr"},
+				{Name: "href", Function: "url", XML: "attr"},
+			}},
+			{Name: "guid", Fields: []Field{
+				{Name: "isPermaLink", Value: false, XML: "attr"},
+				{Name: "value", Value: "a<b", XML: "text"},
+			}},
+			{Name: "summary", Value: "x]]>y", XML: "cdata"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str := string(value)
+	for _, want := range []string{
+		"<!DOCTYPE soap>\n",
+		`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body>`,
+		`<m:GetUser xmlns:m="http://example.com/users"><m:id>`,
+		`<link rel="alternate" href="http`,
+		`<guid isPermaLink="false">a&lt;b</guid>`,
+		`<summary><![CDATA[x]]]]><![CDATA[>y]]></summary>`,
+	} {
+		if !strings.Contains(str, want) {
+			t.Errorf("expected %s in %s", want, str)
+		}
+	}
+
+	// Output is well formed
+	d := xml.NewDecoder(strings.NewReader(str))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("%v: %s", err, str)
+		}
+	}
+}
+
+func TestXMLLookupOptions(t *testing.T) {
+	info := GetFuncLookup("xml")
+
+	m := MapParams{
+		"type":        {"array"},
+		"rootelement": {"feed"},
+		"rowcount":    {"2"},
+		"declaration": {"true"},
+		"namespaces":  {"http://www.w3.org/2005/Atom", "media=http://search.yahoo.com/mrss/"},
+		"fields": {
+			`{"name":"id","function":"uuid","xml":"attr"}`,
+			`{"name":"media:title","function":"sentence"}`,
+		},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str := string(value.([]byte))
+	if !strings.HasPrefix(str, xml.Header+`<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/"><record id="`) {
+		t.Errorf("unexpected feed %s", str)
+	}
+	if strings.Count(str, "<media:title>") != 2 {
+		t.Errorf("expected 2 media titles %s", str)
+	}
+}
@@ -0,0 +1,451 @@
+package gofakeit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// YAMLOptions defines values needed for yaml generation
+type YAMLOptions struct {
+	Type     string  `json:"type" xml:"type" fake:"{randomstring:[array,object]}"` // array or object
+	RowCount int     `json:"row_count" xml:"row_count" fake:"{number:1,10}"`
+	Fields   []Field `json:"fields" xml:"fields" fake:"{fields}"`
+}
+
+// YAML generates an object or an array of objects in yaml format.
+// A nil YAMLOptions returns a randomly structured YAML.
+func YAML(yo *YAMLOptions) ([]byte, error) { return yamlFunc(GlobalFaker, yo) }
+
+// YAML generates an object or an array of objects in yaml format.
+// A nil YAMLOptions returns a randomly structured YAML.
+func (f *Faker) YAML(yo *YAMLOptions) ([]byte, error) { return yamlFunc(f, yo) }
+
+func yamlFunc(f *Faker, yo *YAMLOptions) ([]byte, error) {
+	if yo == nil {
+		// We didn't get a YAMLOptions, so create a new random one
+		err := f.Struct(&yo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check to make sure they passed in a type
+	if yo.Type != "array" && yo.Type != "object" {
+		return nil, errors.New("invalid type, must be array or object")
+	}
+
+	if yo.Fields == nil || len(yo.Fields) <= 0 {
+		return nil, errors.New("must pass fields in order to build yaml object(s)")
+	}
+
+	var value any
+	if yo.Type == "object" {
+		// Object only has one row for autoincrement
+		obj, err := f.fieldObject(yo.Fields, 1, 0, plainLeafValue)
+		if err != nil {
+			return nil, err
+		}
+		value = obj
+	} else {
+		// Make sure you set a row count
+		if yo.RowCount <= 0 {
+			return nil, errors.New("must have row count")
+		}
+
+		rows := make([]any, yo.RowCount)
+		for i := range rows {
+			obj, err := f.fieldObject(yo.Fields, i+1, 0, plainLeafValue)
+			if err != nil {
+				return nil, err
+			}
+			rows[i] = obj
+		}
+		value = rows
+	}
+
+	var buf bytes.Buffer
+	err := yamlBlock(&buf, value, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// yamlBlock will write a mapping or sequence in block style, one entry per line.
+// Inline means the first entry continues the current line after a sequence dash
+func yamlBlock(buf *bytes.Buffer, value any, indent int, inline bool) error {
+	value, err := plainValue(value)
+	if err != nil {
+		return err
+	}
+
+	if keys, values, ok := plainEntries(value); ok && len(keys) > 0 {
+		for i, key := range keys {
+			if i > 0 || !inline {
+				buf.WriteString(strings.Repeat(" ", indent))
+			}
+			buf.WriteString(yamlString(key) + ":")
+
+			err := yamlEntryValue(buf, values[i], indent+2, false)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if items, ok := value.([]any); ok && len(items) > 0 {
+		for i, item := range items {
+			if i > 0 || !inline {
+				buf.WriteString(strings.Repeat(" ", indent))
+			}
+			buf.WriteString("-")
+
+			err := yamlEntryValue(buf, item, indent+2, true)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Scalars and empty collections
+	scalar, err := yamlScalar(value)
+	if err != nil {
+		return err
+	}
+	if inline {
+		buf.WriteString(scalar + "\n")
+	} else {
+		buf.WriteString(strings.Repeat(" ", indent) + scalar + "\n")
+	}
+
+	return nil
+}
+
+// yamlEntryValue will write the value of a mapping key or sequence item. Scalars follow on
+// the same line, collections start on the next line or, after a sequence dash, the same line
+func yamlEntryValue(buf *bytes.Buffer, value any, indent int, dash bool) error {
+	value, err := plainValue(value)
+	if err != nil {
+		return err
+	}
+
+	if !plainCollection(value) {
+		scalar, err := yamlScalar(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(" " + scalar + "\n")
+		return nil
+	}
+
+	if dash {
+		buf.WriteString(" ")
+		return yamlBlock(buf, value, indent, true)
+	}
+
+	buf.WriteString("\n")
+	return yamlBlock(buf, value, indent, false)
+}
+
+// yamlScalar will format a plain value as a yaml scalar, quoting strings that would
+// otherwise be read as another type
+func yamlScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return yamlString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case json.Number:
+		return v.String(), nil
+	case jsonOrderedKeyVal, map[string]any:
+		return "{}", nil
+	case []any:
+		return "[]", nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		fv := rv.Float()
+		switch {
+		case math.IsNaN(fv):
+			return ".nan", nil
+		case math.IsInf(fv, 1):
+			return ".inf", nil
+		case math.IsInf(fv, -1):
+			return "-.inf", nil
+		}
+		return plainFloat(fv, rv.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("yaml cannot output type %T", value)
+}
+
+// yamlReserved are plain scalars yaml 1.1 or 1.2 read as booleans, null, the value key or the merge key
+var yamlReserved = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "true": true, "false": true,
+	"on": true, "off": true, "null": true, "~": true, "=": true, "<<": true,
+}
+
+// yamlString will return the string as a plain scalar if it can only be read as a string,
+// otherwise it is double quoted
+func yamlString(s string) string {
+	if yamlPlain(s) {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || (r > 0x7f && !unicode.IsPrint(r)) {
+				if r <= 0xff {
+					sb.WriteString(fmt.Sprintf(`\x%02X`, r))
+				} else {
+					sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+				}
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// yamlPlain will check if a string can be written unquoted and still be read as the same string
+func yamlPlain(s string) bool {
+	if s == "" || !utf8.ValidString(s) || yamlReserved[strings.ToLower(s)] {
+		return false
+	}
+
+	// Leading and trailing spaces are trimmed from plain scalars
+	if s[0] == ' ' || s[len(s)-1] == ' ' {
+		return false
+	}
+
+	// Indicators that start other node types
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`", rune(s[0])) {
+		return false
+	}
+
+	// Anything that could be read as a number. Ex: 1e3, 0x1F, .5, 1_000, 12:30, .inf
+	if s[0] >= '0' && s[0] <= '9' || s[0] == '.' || s[0] == '+' {
+		return false
+	}
+
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f || (r != ' ' && !unicode.IsPrint(r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// plainValue will convert a generated value into the types written by the yaml and toml emitters:
+// nil, string, bool, time.Time, json.Number, numbers, jsonOrderedKeyVal, map[string]any and []any
+func plainValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil, string, bool, time.Time, json.Number, jsonOrderedKeyVal, map[string]any, []any:
+		return value, nil
+	case []byte:
+		return string(v), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return value, nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return plainValue(rv.Elem().Interface())
+	}
+
+	// Slices, maps and structs are converted through their json representation
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return plainLeafValue(b)
+}
+
+// plainLeafValue will decode json byte values, keeping numbers as they were written
+func plainLeafValue(value any) (any, error) {
+	b, ok := value.([]byte)
+	if !ok {
+		return value, nil
+	}
+
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// plainEntries will return the keys and values of an ordered object or a map sorted by key
+func plainEntries(value any) ([]string, []any, bool) {
+	switch v := value.(type) {
+	case jsonOrderedKeyVal:
+		keys := make([]string, len(v))
+		values := make([]any, len(v))
+		for i, kv := range v {
+			keys[i] = kv.Key
+			values[i] = kv.Value
+		}
+		return keys, values, true
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		values := make([]any, len(keys))
+		for i, key := range keys {
+			values[i] = v[key]
+		}
+		return keys, values, true
+	}
+
+	return nil, nil, false
+}
+
+// plainCollection will check if a plain value is a non empty object or array
+func plainCollection(value any) bool {
+	switch v := value.(type) {
+	case jsonOrderedKeyVal:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	}
+
+	return false
+}
+
+// plainFloat will format a float so it is always read back as a float, never an integer.
+// The mantissa always has a decimal point, as yaml 1.1 reads 1e+300 as a string
+func plainFloat(v float64, bits int) string {
+	s := strconv.FormatFloat(v, 'g', -1, bits)
+	if abs := math.Abs(v); abs == 0 || abs >= 1e-6 && abs < 1e21 {
+		s = strconv.FormatFloat(v, 'f', -1, bits)
+	}
+	mantissa, exponent, _ := strings.Cut(s, "e")
+	if !strings.Contains(mantissa, ".") {
+		s = mantissa + ".0"
+		if exponent != "" {
+			s += "e" + exponent
+		}
+	}
+
+	return s
+}
+
+func addFileYAMLLookup() {
+	AddFuncLookup("yaml", Info{
+		Display:     "YAML",
+		Category:    "file",
+		Description: "Human readable data format often used for configuration files, returns an object or an array of objects",
+		Example: `- first_name: Markus
+  last_name: Moen
+  password: Dc0VYXjkWABx
+- first_name: Osborne
+  last_name: Hilll
+  password: XPJ9OVNbs5lm`,
+		Output:      "[]byte",
+		ContentType: "application/yaml",
+		Params: []Param{
+			{Field: "type", Display: "Type", Type: "string", Default: "object", Options: []string{"object", "array"}, Description: "Type of YAML, object or array"},
+			{Field: "rowcount", Display: "Row Count", Type: "int", Default: "100", Min: "1", Description: "Number of rows in YAML array"},
+			{Field: "fields", Display: "Fields", Type: "[]Field", Description: "Fields containing key name and function to run in json format"},
+		},
+		Generate: func(f *Faker, m *MapParams, info *Info) (any, error) {
+			yo := YAMLOptions{}
+
+			typ, err := info.GetString(m, "type")
+			if err != nil {
+				return nil, err
+			}
+			yo.Type = typ
+
+			rowcount, err := info.GetInt(m, "rowcount")
+			if err != nil {
+				return nil, err
+			}
+			yo.RowCount = rowcount
+
+			fieldsStr, err := info.GetStringArray(m, "fields")
+			if err != nil {
+				return nil, err
+			}
+
+			// Check to make sure fields has length
+			if len(fieldsStr) > 0 {
+				yo.Fields = make([]Field, len(fieldsStr))
+
+				for i, f := range fieldsStr {
+					// Unmarshal fields string into fields array
+					err = json.Unmarshal([]byte(f), &yo.Fields[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return yamlFunc(f, &yo)
+		},
+	})
+}
@@ -0,0 +1,246 @@
+package gofakeit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleYAML() {
+	Seed(11)
+
+	value, err := YAML(&YAMLOptions{
+		Type: "object",
+		Fields: []Field{
+			{Name: "first_name", Function: "firstname"},
+			{Name: "last_name", Function: "lastname"},
+			{Name: "address", Fields: []Field{
+				{Name: "city", Function: "city"},
+				{Name: "zip", Function: "zip"},
+			}},
+			{Name: "tags", Function: "word", Count: 2},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output: first_name: Sonny
+	// last_name: Stiedemann
+	// address:
+	//   city: Memphis
+	//   zip: "52759"
+	// tags:
+	//   - they
+	//   - how
+}
+
+func ExampleFaker_YAML() {
+	f := New(11)
+
+	value, err := f.YAML(&YAMLOptions{
+		Type:     "array",
+		RowCount: 2,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "enabled", Function: "bool"},
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(value))
+
+	// Output: - id: 1
+	//   first_name: Sonny
+	//   enabled: false
+	// - id: 2
+	//   first_name: Cody
+	//   enabled: true
+}
+
+func TestYAMLScalars(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"hello world", "hello world"},
+		{"", `""`},
+		{"yes", `"yes"`},
+		{"No", `"No"`},
+		{"on", `"on"`},
+		{"null", `"null"`},
+		{"~", `"~"`},
+		{"=", `"="`},
+		{"<<", `"<<"`},
+		{"true", `"true"`},
+		{"1e3", `"1e3"`},
+		{"123", `"123"`},
+		{"0x1F", `"0x1F"`},
+		{".inf", `".inf"`},
+		{"+1", `"+1"`},
+		{"-1", `"-1"`},
+		{"12:30", `"12:30"`},
+		{"key: value", `"key: value"`},
+		{"a #comment", `"a #comment"`},
+		{"- item", `"- item"`},
+		{" padded ", `" padded "`},
+		{"line\nbreak", `"line\nbreak"`},
+		{`say "hi"`, `say "hi"`},
+		{`"quoted"`, `"\"quoted\""`},
+		{"tab\there", `"tab\there"`},
+		{"café", "café"},
+		{nil, "null"},
+		{true, "true"},
+		{42, "42"},
+		{uint8(7), "7"},
+		{2.0, "2.0"},
+		{1.5, "1.5"},
+		{float32(0.1), "0.1"},
+		{1e21, "1.0e+21"},
+		{1e300, "1.0e+300"},
+		{-1e300, "-1.0e+300"},
+		{1.5e300, "1.5e+300"},
+		{1e-7, "1.0e-07"},
+		{math.Inf(1), ".inf"},
+		{math.NaN(), ".nan"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "2024-01-02T03:04:05Z"},
+	}
+
+	for _, test := range tests {
+		value, err := plainValue(test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := yamlScalar(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%#v: got %s want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestYAMLNested(t *testing.T) {
+	value, err := New(11).YAML(&YAMLOptions{
+		Type:     "array",
+		RowCount: 2,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "answer", Value: "no"},
+			{Name: "empty", Value: []string{}},
+			{Name: "user", Fields: []Field{
+				{Name: "roles", Value: []string{"admin", "1"}},
+				{Name: "meta", Value: map[string]any{"b": 2, "a": nil}},
+			}},
+			{Name: "items", Count: 2, Fields: []Field{
+				{Name: "line", Function: "autoincrement"},
+				{Name: "matrix", Value: [][]int{{1, 2}, {3}}},
+			}},
+			{Name: "json", Function: "json", Params: MapParams{"type": {"object"}, "fields": {`{"name":"count","value":5}`}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := `  answer: "no"
+  empty: []
+  user:
+    roles:
+      - admin
+      - "1"
+    meta:
+      a: null
+      b: 2
+  items:
+    - line: 1
+      matrix:
+        - - 1
+          - 2
+        - - 3
+    - line: 2
+      matrix:
+        - - 1
+          - 2
+        - - 3
+  json:
+    count: 5
+`
+	want := "- id: 1\n" + row + "- id: 2\n" + row
+	if string(value) != want {
+		t.Errorf("got\n%s\nwant\n%s", value, want)
+	}
+}
+
+func TestYAMLErrors(t *testing.T) {
+	tests := map[string]*YAMLOptions{
+		"invalid type": {Type: "list", Fields: []Field{{Name: "a", Function: "city"}}},
+		"no fields":    {Type: "object"},
+		"no row count": {Type: "array", Fields: []Field{{Name: "a", Function: "city"}}},
+		"bad function": {Type: "object", Fields: []Field{{Name: "a", Function: "notafunction"}}},
+	}
+
+	for name, yo := range tests {
+		if _, err := YAML(yo); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestYAMLNil(t *testing.T) {
+	value, err := New(11).YAML(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(value) == 0 {
+		t.Error("expected random yaml")
+	}
+}
+
+func TestYAMLLookup(t *testing.T) {
+	info := GetFuncLookup("yaml")
+
+	m := MapParams{
+		"type":     {"array"},
+		"rowcount": {"3"},
+		"fields": {
+			`{"name":"id","function":"autoincrement"}`,
+			`{"name":"first_name","function":"firstname"}`,
+		},
+	}
+
+	value, err := info.Generate(New(11), &m, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(string(value.([]byte)), "- id: ") != 3 {
+		t.Errorf("expected 3 rows, got %s", value)
+	}
+}
+
+func BenchmarkYAML(b *testing.B) {
+	f := New(11)
+	yo := &YAMLOptions{
+		Type:     "array",
+		RowCount: 10,
+		Fields: []Field{
+			{Name: "id", Function: "autoincrement"},
+			{Name: "first_name", Function: "firstname"},
+			{Name: "address", Fields: []Field{{Name: "city", Function: "city"}}},
+		},
+	}
+
+	for i := 0; i < b.N; i++ {
+		f.YAML(yo)
+	}
+}